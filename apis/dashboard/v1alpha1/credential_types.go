@@ -0,0 +1,206 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// A CredentialScope limits where a credential can be used.
+type CredentialScope string
+
+// Credential scopes.
+const (
+	// CredentialScopeGlobal credentials can be used by jobs and by Jenkins.
+	CredentialScopeGlobal CredentialScope = "Global"
+
+	// CredentialScopeSystem credentials can only be used by Jenkins itself,
+	// e.g. to connect agents. They are not supported in folders.
+	CredentialScopeSystem CredentialScope = "System"
+)
+
+// UsernamePasswordCredential is a username with a password.
+type UsernamePasswordCredential struct {
+	// Username of the credential.
+	Username string `json:"username"`
+
+	// PasswordSecretRef references the password.
+	PasswordSecretRef xpv1.SecretKeySelector `json:"passwordSecretRef"`
+}
+
+// SecretTextCredential is a secret string such as an API token.
+type SecretTextCredential struct {
+	// SecretRef references the secret text.
+	SecretRef xpv1.SecretKeySelector `json:"secretRef"`
+}
+
+// SSHPrivateKeyCredential is an SSH username with a private key.
+type SSHPrivateKeyCredential struct {
+	// Username of the credential.
+	Username string `json:"username"`
+
+	// PrivateKeySecretRef references the PEM encoded private key.
+	PrivateKeySecretRef xpv1.SecretKeySelector `json:"privateKeySecretRef"`
+
+	// PassphraseSecretRef references the passphrase of the private key.
+	// +optional
+	PassphraseSecretRef *xpv1.SecretKeySelector `json:"passphraseSecretRef,omitempty"`
+}
+
+// CertificateCredential is a client certificate and its private key.
+type CertificateCredential struct {
+	// KeyStoreSecretRef references a PKCS#12 key store containing the
+	// certificate and its private key.
+	KeyStoreSecretRef xpv1.SecretKeySelector `json:"keyStoreSecretRef"`
+
+	// PasswordSecretRef references the password of the key store.
+	// +optional
+	PasswordSecretRef *xpv1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+}
+
+// FileCredential is a secret file.
+type FileCredential struct {
+	// FileName is the name of the file as seen by jobs.
+	FileName string `json:"fileName"`
+
+	// SecretRef references the content of the file.
+	SecretRef xpv1.SecretKeySelector `json:"secretRef"`
+}
+
+// CredentialParameters are the configurable fields of a Credential. Exactly
+// one of usernamePassword, secretText, sshPrivateKey, certificate and file
+// must be set. Secret material is read from Kubernetes Secrets and is never
+// reported in the status of the Credential.
+type CredentialParameters struct {
+	// ID of the credential, used by jobs to refer to it.
+	ID string `json:"id"`
+
+	// Description of the credential.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Scope of the credential.
+	// +optional
+	// +kubebuilder:validation:Enum=Global;System
+	// +kubebuilder:default=Global
+	Scope CredentialScope `json:"scope,omitempty"`
+
+	// Folder is the full name of the folder whose credential store contains
+	// the credential. The system credential store is used if it is empty.
+	// +optional
+	// +crossplane:generate:reference:type=Folder
+	Folder string `json:"folder,omitempty"`
+
+	// FolderRef references the Folder whose credential store contains the
+	// credential.
+	// +optional
+	FolderRef *xpv1.Reference `json:"folderRef,omitempty"`
+
+	// FolderSelector selects a reference to the Folder whose credential
+	// store contains the credential.
+	// +optional
+	FolderSelector *xpv1.Selector `json:"folderSelector,omitempty"`
+
+	// Domain is the name of the credential domain containing the credential.
+	// The global domain is used if it is empty.
+	// +optional
+	Domain string `json:"domain,omitempty"`
+
+	// +optional
+	UsernamePassword *UsernamePasswordCredential `json:"usernamePassword,omitempty"`
+
+	// +optional
+	SecretText *SecretTextCredential `json:"secretText,omitempty"`
+
+	// +optional
+	SSHPrivateKey *SSHPrivateKeyCredential `json:"sshPrivateKey,omitempty"`
+
+	// +optional
+	Certificate *CertificateCredential `json:"certificate,omitempty"`
+
+	// +optional
+	File *FileCredential `json:"file,omitempty"`
+}
+
+// CredentialObservation are the observable fields of a Credential.
+type CredentialObservation struct {
+	// FullName of the credential, including its store and domain.
+	FullName string `json:"fullName,omitempty"`
+
+	// DisplayName of the credential.
+	DisplayName string `json:"displayName,omitempty"`
+
+	// TypeName is the kind of credential as reported by Jenkins.
+	TypeName string `json:"typeName,omitempty"`
+}
+
+// A CredentialSpec defines the desired state of a Credential.
+type CredentialSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       CredentialParameters `json:"forProvider"`
+}
+
+// A CredentialStatus represents the observed state of a Credential.
+type CredentialStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          CredentialObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Credential is a Jenkins credential whose secret material is read from
+// Kubernetes Secrets.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=".status.atProvider.typeName"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,jenkins}
+type Credential struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CredentialSpec   `json:"spec"`
+	Status CredentialStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CredentialList contains a list of Credential
+type CredentialList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Credential `json:"items"`
+}
+
+// Credential type metadata.
+var (
+	CredentialKind             = reflect.TypeOf(Credential{}).Name()
+	CredentialGroupKind        = schema.GroupKind{Group: Group, Kind: CredentialKind}.String()
+	CredentialKindAPIVersion   = CredentialKind + "." + SchemeGroupVersion.String()
+	CredentialGroupVersionKind = SchemeGroupVersion.WithKind(CredentialKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Credential{}, &CredentialList{})
+}
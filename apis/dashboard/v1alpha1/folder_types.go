@@ -0,0 +1,149 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// A FolderHealthMetricType is a way of computing the health of a folder from
+// the items it contains.
+type FolderHealthMetricType string
+
+// Folder health metric types.
+const (
+	// FolderHealthMetricWorstChildHealth reports the health of the least
+	// healthy item in the folder.
+	FolderHealthMetricWorstChildHealth FolderHealthMetricType = "WorstChildHealth"
+)
+
+// A FolderHealthMetric computes the health of a folder.
+type FolderHealthMetric struct {
+	// Type of the health metric.
+	// +kubebuilder:validation:Enum=WorstChildHealth
+	Type FolderHealthMetricType `json:"type"`
+
+	// Recursive includes the items of nested folders.
+	// +optional
+	// +kubebuilder:default=true
+	Recursive *bool `json:"recursive,omitempty"`
+}
+
+// FolderParameters are the configurable fields of a Folder.
+type FolderParameters struct {
+	// Name of the folder.
+	Name string `json:"name"`
+
+	// Parent is the full name of the folder containing this folder, e.g.
+	// "team/sub". The folder is created at the root of Jenkins if it is
+	// empty. Parent folders must exist before the folder can be created.
+	// +optional
+	Parent string `json:"parent,omitempty"`
+
+	// DisplayName of the folder. The name is displayed if it is empty.
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+
+	// Description of the folder.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Properties is the XML content of the properties element of the folder's
+	// config.xml, e.g. folder scoped pipeline libraries or environment
+	// variables contributed by plugins. Only the declared properties are
+	// managed; other properties of the folder, e.g. the folder scoped
+	// credentials of Credentials, are preserved.
+	// +optional
+	Properties string `json:"properties,omitempty"`
+
+	// HealthMetrics compute the health of the folder from the items it
+	// contains.
+	// +optional
+	HealthMetrics []FolderHealthMetric `json:"healthMetrics,omitempty"`
+}
+
+// FolderObservation are the observable fields of a Folder.
+type FolderObservation struct {
+	// FullName of the folder, including its parent folders.
+	FullName string `json:"fullName,omitempty"`
+
+	// URL of the folder.
+	URL string `json:"url,omitempty"`
+
+	// HealthScore is the lowest score of the health reports of the folder,
+	// from 0 to 100.
+	HealthScore *int64 `json:"healthScore,omitempty"`
+
+	// Items are the names of the jobs and folders the folder contains.
+	Items []string `json:"items,omitempty"`
+}
+
+// A FolderSpec defines the desired state of a Folder.
+type FolderSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       FolderParameters `json:"forProvider"`
+}
+
+// A FolderStatus represents the observed state of a Folder.
+type FolderStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          FolderObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Folder is a Jenkins folder that can contain jobs and other folders.
+// Deleting a Folder deletes everything it contains.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,jenkins}
+type Folder struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FolderSpec   `json:"spec"`
+	Status FolderStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// FolderList contains a list of Folder
+type FolderList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Folder `json:"items"`
+}
+
+// Folder type metadata.
+var (
+	FolderKind             = reflect.TypeOf(Folder{}).Name()
+	FolderGroupKind        = schema.GroupKind{Group: Group, Kind: FolderKind}.String()
+	FolderKindAPIVersion   = FolderKind + "." + SchemeGroupVersion.String()
+	FolderGroupVersionKind = SchemeGroupVersion.WithKind(FolderKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Folder{}, &FolderList{})
+}
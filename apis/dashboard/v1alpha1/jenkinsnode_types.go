@@ -31,7 +31,273 @@ type JenkinsNodeParameters struct {
 	NumExecutors int64  `json:"numExecutors"`
 	Description  string `json:"description"`
 	RemoteFS     string `json:"remoteFS"`
-	Label        string `json:"label"`
+
+	// Label is a whitespace separated list of labels of the node.
+	// Deprecated: Use Labels.
+	// +optional
+	Label string `json:"label,omitempty"`
+
+	// Labels of the node. Labels must not contain whitespace. They are
+	// appended to those of Label.
+	// +optional
+	Labels []string `json:"labels,omitempty"`
+
+	// Mode determines whether the node runs any build it can, or only builds
+	// restricted to one of its labels. The mode of the node is not managed
+	// if it is omitted; nodes are created in the Normal mode.
+	// +kubebuilder:validation:Enum=Normal;Exclusive
+	// +optional
+	Mode *JenkinsNodeMode `json:"mode,omitempty"`
+
+	// RetentionStrategy determines when Jenkins keeps the agent of the node
+	// online. The retention strategy of the node is not managed if it is
+	// omitted; nodes are created with the Always strategy.
+	// +optional
+	RetentionStrategy *RetentionStrategy `json:"retentionStrategy,omitempty"`
+
+	// NodeProperties of the node. The node properties are not managed if
+	// they are omitted. Properties of other kinds, such as those contributed
+	// by plugins, are always preserved.
+	// +optional
+	NodeProperties *JenkinsNodeProperties `json:"nodeProperties,omitempty"`
+
+	// Offline marks the node temporarily offline, e.g. to drain it before
+	// maintenance. Jenkins does not start new builds on the node, but lets
+	// running builds finish.
+	// +optional
+	Offline bool `json:"offline,omitempty"`
+
+	// OfflineMessage explains why the node is offline. Ignored unless
+	// Offline is true.
+	// +optional
+	OfflineMessage string `json:"offlineMessage,omitempty"`
+
+	// Launcher configures how Jenkins starts the agent of the node. The
+	// launcher of the node is not managed if it is omitted; nodes created
+	// without a launcher use an inbound agent.
+	// +optional
+	Launcher *JenkinsNodeLauncher `json:"launcher,omitempty"`
+}
+
+// A JenkinsNodeMode determines which builds a node runs.
+type JenkinsNodeMode string
+
+// Node modes.
+const (
+	// JenkinsNodeModeNormal nodes run any build they can.
+	JenkinsNodeModeNormal JenkinsNodeMode = "Normal"
+
+	// JenkinsNodeModeExclusive nodes only run builds whose label expression
+	// matches the node.
+	JenkinsNodeModeExclusive JenkinsNodeMode = "Exclusive"
+)
+
+// A RetentionStrategy determines when Jenkins keeps the agent of a node
+// online. Exactly one strategy must be specified.
+type RetentionStrategy struct {
+	// Always keeps the agent online.
+	// +optional
+	Always *AlwaysRetentionStrategy `json:"always,omitempty"`
+
+	// OnDemand starts the agent when builds are waiting for it, and stops it
+	// when it has been idle.
+	// +optional
+	OnDemand *OnDemandRetentionStrategy `json:"onDemand,omitempty"`
+
+	// Scheduled keeps the agent online during scheduled periods.
+	// +optional
+	Scheduled *ScheduledRetentionStrategy `json:"scheduled,omitempty"`
+}
+
+// An AlwaysRetentionStrategy keeps the agent of a node online.
+type AlwaysRetentionStrategy struct{}
+
+// An OnDemandRetentionStrategy keeps the agent of a node online while there is
+// demand for it.
+type OnDemandRetentionStrategy struct {
+	// InDemandDelayMinutes is how long builds must have been waiting for the
+	// node before its agent is started.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	InDemandDelayMinutes int64 `json:"inDemandDelayMinutes,omitempty"`
+
+	// IdleDelayMinutes is how long the agent must have been idle before it
+	// is stopped.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=1
+	// +optional
+	IdleDelayMinutes int64 `json:"idleDelayMinutes,omitempty"`
+}
+
+// A ScheduledRetentionStrategy keeps the agent of a node online during
+// scheduled periods.
+type ScheduledRetentionStrategy struct {
+	// StartTimeSpec is a cron expression of the times the agent is started,
+	// e.g. "0 8 * * 1-5".
+	StartTimeSpec string `json:"startTimeSpec"`
+
+	// UpTimeMinutes is how long the agent is kept online once started.
+	// +kubebuilder:validation:Minimum=1
+	UpTimeMinutes int64 `json:"upTimeMinutes"`
+
+	// KeepUpWhenActive keeps the agent online past its scheduled period
+	// while it is running builds.
+	// +optional
+	KeepUpWhenActive bool `json:"keepUpWhenActive,omitempty"`
+}
+
+// JenkinsNodeProperties are the node properties of a JenkinsNode. A property
+// that is omitted is removed from the node.
+type JenkinsNodeProperties struct {
+	// EnvironmentVariables are set for builds running on the node.
+	// +optional
+	EnvironmentVariables []EnvironmentVariable `json:"environmentVariables,omitempty"`
+
+	// ToolLocations override the home directory of tool installations on
+	// the node.
+	// +optional
+	ToolLocations []ToolLocation `json:"toolLocations,omitempty"`
+
+	// DiskSpaceMonitoring overrides the global disk space thresholds for the
+	// node.
+	// +optional
+	DiskSpaceMonitoring *DiskSpaceMonitoringThresholds `json:"diskSpaceMonitoring,omitempty"`
+}
+
+// An EnvironmentVariable is set for builds running on a node.
+type EnvironmentVariable struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// A ToolLocation is the home directory of a tool installation on a node.
+type ToolLocation struct {
+	// Type is the class of the descriptor of the tool installation, e.g.
+	// "hudson.model.JDK$DescriptorImpl" or
+	// "hudson.tasks.Maven$MavenInstallation$DescriptorImpl".
+	Type string `json:"type"`
+
+	// Name of the tool installation.
+	Name string `json:"name"`
+
+	// Home directory of the tool installation on the node.
+	Home string `json:"home"`
+}
+
+// DiskSpaceMonitoringThresholds are the disk space thresholds of a node.
+// Thresholds are sizes such as "1GiB" or "500MiB".
+type DiskSpaceMonitoringThresholds struct {
+	// FreeDiskSpaceThreshold below which the node is taken offline.
+	// +optional
+	FreeDiskSpaceThreshold string `json:"freeDiskSpaceThreshold,omitempty"`
+
+	// FreeDiskSpaceWarningThreshold below which a warning is shown.
+	// +optional
+	FreeDiskSpaceWarningThreshold string `json:"freeDiskSpaceWarningThreshold,omitempty"`
+
+	// FreeTempSpaceThreshold below which the node is taken offline.
+	// +optional
+	FreeTempSpaceThreshold string `json:"freeTempSpaceThreshold,omitempty"`
+
+	// FreeTempSpaceWarningThreshold below which a warning is shown.
+	// +optional
+	FreeTempSpaceWarningThreshold string `json:"freeTempSpaceWarningThreshold,omitempty"`
+}
+
+// A JenkinsNodeLauncher configures how Jenkins starts the agent of a node.
+// Exactly one launcher must be specified.
+type JenkinsNodeLauncher struct {
+	// Inbound agents connect to Jenkins themselves, e.g. using the
+	// jenkins/inbound-agent image.
+	// +optional
+	Inbound *InboundLauncher `json:"inbound,omitempty"`
+
+	// SSH agents are started by Jenkins over SSH. Requires the SSH Build
+	// Agents plugin.
+	// +optional
+	SSH *SSHLauncher `json:"ssh,omitempty"`
+
+	// Command agents are started by Jenkins running a command on the
+	// controller.
+	// +optional
+	Command *CommandLauncher `json:"command,omitempty"`
+}
+
+// An InboundLauncher lets an agent connect to Jenkins using the inbound agent
+// protocol.
+type InboundLauncher struct {
+	// WebSocket connects the agent over the HTTP(S) port of Jenkins instead
+	// of the TCP agent port.
+	// +optional
+	WebSocket bool `json:"webSocket,omitempty"`
+
+	// Tunnel is the host:port the agent connects to instead of the TCP agent
+	// port advertised by Jenkins. Ignored if WebSocket is true.
+	// +optional
+	Tunnel string `json:"tunnel,omitempty"`
+}
+
+// An SSHHostKeyVerificationStrategy determines how Jenkins verifies the host
+// key of an SSH agent.
+type SSHHostKeyVerificationStrategy string
+
+// SSH host key verification strategies.
+const (
+	// SSHHostKeyKnownHosts verifies the host key using the known_hosts file
+	// of the user running Jenkins.
+	SSHHostKeyKnownHosts SSHHostKeyVerificationStrategy = "KnownHosts"
+
+	// SSHHostKeyManuallyProvided verifies the host key against HostKey.
+	SSHHostKeyManuallyProvided SSHHostKeyVerificationStrategy = "ManuallyProvided"
+
+	// SSHHostKeyManuallyTrusted trusts the host key seen on the first
+	// connection.
+	SSHHostKeyManuallyTrusted SSHHostKeyVerificationStrategy = "ManuallyTrusted"
+
+	// SSHHostKeyNonVerifying does not verify the host key.
+	SSHHostKeyNonVerifying SSHHostKeyVerificationStrategy = "NonVerifying"
+)
+
+// An SSHLauncher starts an agent over SSH.
+type SSHLauncher struct {
+	// Host to connect to.
+	Host string `json:"host"`
+
+	// Port to connect to.
+	// +kubebuilder:default=22
+	// +optional
+	Port *int64 `json:"port,omitempty"`
+
+	// CredentialsID is the ID of the Jenkins credential used to log in, e.g.
+	// a Credential with an sshPrivateKey.
+	CredentialsID string `json:"credentialsId"`
+
+	// JVMOptions are passed to the JVM running the agent.
+	// +optional
+	JVMOptions string `json:"jvmOptions,omitempty"`
+
+	// JavaPath is the path of the java executable on the agent. Jenkins
+	// looks it up if it is empty.
+	// +optional
+	JavaPath string `json:"javaPath,omitempty"`
+
+	// HostKeyVerificationStrategy determines how the host key of the agent
+	// is verified.
+	// +kubebuilder:validation:Enum=KnownHosts;ManuallyProvided;ManuallyTrusted;NonVerifying
+	// +kubebuilder:default=KnownHosts
+	// +optional
+	HostKeyVerificationStrategy *SSHHostKeyVerificationStrategy `json:"hostKeyVerificationStrategy,omitempty"`
+
+	// HostKey is the expected host key in known_hosts format, e.g.
+	// "ssh-ed25519 AAAA...". Required by the ManuallyProvided strategy.
+	// +optional
+	HostKey string `json:"hostKey,omitempty"`
+}
+
+// A CommandLauncher starts an agent by running a command on the controller.
+type CommandLauncher struct {
+	// Command that starts the agent, e.g. "ssh agent java -jar agent.jar".
+	Command string `json:"command"`
 }
 
 // JenkinsNodeObservation are the observable fields of a JenkinsNode.
@@ -41,6 +307,40 @@ type JenkinsNodeObservation struct {
 	Description  string `json:"description"`
 	RemoteFS     string `json:"remoteFS"`
 	Label        string `json:"label"`
+
+	// Offline is true if the node cannot run builds, e.g. because its agent
+	// is not connected.
+	Offline bool `json:"offline,omitempty"`
+
+	// TemporarilyOffline is true if the node was marked offline by a user.
+	TemporarilyOffline bool `json:"temporarilyOffline,omitempty"`
+
+	// OfflineCause explains why the node is offline.
+	OfflineCause string `json:"offlineCause,omitempty"`
+
+	// IdleExecutors is the number of executors that are not running a build.
+	IdleExecutors int64 `json:"idleExecutors,omitempty"`
+
+	// BusyExecutors is the number of executors that are running a build.
+	BusyExecutors int64 `json:"busyExecutors,omitempty"`
+
+	// Drained is true if the node is temporarily offline and none of its
+	// executors is running a build.
+	Drained bool `json:"drained,omitempty"`
+
+	// JNLPAgent is true if the node is connected by an inbound agent.
+	JNLPAgent bool `json:"jnlpAgent,omitempty"`
+
+	// FreeDiskSpaceBytes is the free disk space of the remote root directory
+	// as reported by the disk space monitor.
+	FreeDiskSpaceBytes *int64 `json:"freeDiskSpaceBytes,omitempty"`
+
+	// ResponseTimeMillis is the average response time of the agent as
+	// reported by the response time monitor.
+	ResponseTimeMillis *int64 `json:"responseTimeMillis,omitempty"`
+
+	// Architecture of the agent, e.g. "Linux (amd64)".
+	Architecture string `json:"architecture,omitempty"`
 }
 
 // A JenkinsNodeSpec defines the desired state of a JenkinsNode.
@@ -61,6 +361,8 @@ type JenkinsNodeStatus struct {
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="OFFLINE",type="boolean",JSONPath=".status.atProvider.offline"
+// +kubebuilder:printcolumn:name="BUSY",type="integer",JSONPath=".status.atProvider.busyExecutors"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,jenkins}
@@ -27,14 +27,303 @@ import (
 
 // JobParameters are the configurable fields of a Job.
 type JobParameters struct {
-	Name   string `json:"name"`
-	Parent string `json:"parent"`
-	Config string `json:"config"`
+	Name string `json:"name"`
+
+	// Parent is the full name of the folder containing the job, e.g.
+	// "team/sub". The job is created at the root of Jenkins if it is empty.
+	// +optional
+	// +crossplane:generate:reference:type=Folder
+	Parent string `json:"parent,omitempty"`
+
+	// ParentRef references the Folder containing the job. The full name of
+	// the folder is taken from its external name.
+	// +optional
+	ParentRef *xpv1.Reference `json:"parentRef,omitempty"`
+
+	// ParentSelector selects a reference to the Folder containing the job.
+	// +optional
+	ParentSelector *xpv1.Selector `json:"parentSelector,omitempty"`
+
+	// Config is the config.xml of the job. Exactly one of Config,
+	// ConfigFrom, Pipeline and Template must be specified.
+	// +optional
+	Config string `json:"config,omitempty"`
+
+	// ConfigFrom loads the config.xml of the job from a key of a ConfigMap
+	// or Secret. The job is updated whenever the key changes.
+	// +optional
+	ConfigFrom *JobConfigSource `json:"configFrom,omitempty"`
+
+	// Pipeline configures a Pipeline job. The config.xml of the job is
+	// generated from it.
+	// +optional
+	Pipeline *Pipeline `json:"pipeline,omitempty"`
+
+	// Template is a Go text/template that renders the config.xml of the job
+	// from TemplateVariables, e.g. <url>{{ .repoURL | xml }}</url>. The xml
+	// function escapes a value for use in XML.
+	// +optional
+	Template *JobTemplate `json:"template,omitempty"`
+
+	// TemplateVariables are the variables the Template is rendered with.
+	// Referencing a variable that is not set is an error.
+	// +optional
+	TemplateVariables map[string]string `json:"templateVariables,omitempty"`
+
+	// IgnorePluginVersions ignores plugin attributes, e.g.
+	// plugin="workflow-job@1254.v3f64639b_11dd", when comparing the desired
+	// config with the one stored by Jenkins.
+	// +optional
+	// +kubebuilder:default=true
+	IgnorePluginVersions *bool `json:"ignorePluginVersions,omitempty"`
+}
+
+// A JobConfigSource references the config.xml of a Job. Exactly one source
+// must be specified.
+type JobConfigSource struct {
+	// ConfigMapKeyRef references a key of a ConfigMap.
+	// +optional
+	ConfigMapKeyRef *ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// SecretKeyRef references a key of a Secret.
+	// +optional
+	SecretKeyRef *xpv1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// A JobTemplate is a Go text/template of the config.xml of a Job. Exactly one
+// source must be specified.
+type JobTemplate struct {
+	// Inline template.
+	// +optional
+	Inline string `json:"inline,omitempty"`
+
+	// ConfigMapKeyRef references a key of a ConfigMap containing the
+	// template.
+	// +optional
+	ConfigMapKeyRef *ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+}
+
+// A ConfigMapKeySelector is a reference to a key of a ConfigMap in an
+// arbitrary namespace.
+type ConfigMapKeySelector struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+
+	// Key within the ConfigMap.
+	Key string `json:"key"`
+}
+
+// A Pipeline configures a Pipeline job.
+type Pipeline struct {
+	// Description of the job.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Disabled prevents the job from being built.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+
+	// Script is an inline Jenkinsfile. Exactly one of Script and Git must be
+	// specified.
+	// +optional
+	Script string `json:"script,omitempty"`
+
+	// Sandbox runs the inline Jenkinsfile in the Groovy sandbox. Scripts
+	// that do not use the sandbox must be approved by an administrator.
+	// +optional
+	// +kubebuilder:default=true
+	Sandbox *bool `json:"sandbox,omitempty"`
+
+	// Git loads the Jenkinsfile from a Git repository.
+	// +optional
+	Git *PipelineGitSource `json:"git,omitempty"`
+
+	// Parameters of the job.
+	// +optional
+	Parameters []PipelineParameter `json:"parameters,omitempty"`
+
+	// Triggers start the job automatically.
+	// +optional
+	Triggers *PipelineTriggers `json:"triggers,omitempty"`
+
+	// BuildDiscarder limits how many builds and artifacts are kept.
+	// +optional
+	BuildDiscarder *BuildDiscarder `json:"buildDiscarder,omitempty"`
+}
+
+// A PipelineGitSource loads the Jenkinsfile of a Pipeline from a Git
+// repository. Requires the Git plugin.
+type PipelineGitSource struct {
+	// URL of the repository.
+	URL string `json:"url"`
+
+	// Branch to build, e.g. "*/main".
+	// +optional
+	// +kubebuilder:default="*/main"
+	Branch string `json:"branch,omitempty"`
+
+	// CredentialsID is the ID of the Jenkins credential used to clone the
+	// repository.
+	// +optional
+	CredentialsID string `json:"credentialsId,omitempty"`
+
+	// ScriptPath is the path of the Jenkinsfile in the repository.
+	// +optional
+	// +kubebuilder:default=Jenkinsfile
+	ScriptPath string `json:"scriptPath,omitempty"`
+
+	// Lightweight fetches only the Jenkinsfile rather than checking out the
+	// whole repository.
+	// +optional
+	// +kubebuilder:default=true
+	Lightweight *bool `json:"lightweight,omitempty"`
+}
+
+// A PipelineParameterType is the type of a Pipeline parameter.
+type PipelineParameterType string
+
+// Pipeline parameter types.
+const (
+	PipelineParameterString  PipelineParameterType = "String"
+	PipelineParameterText    PipelineParameterType = "Text"
+	PipelineParameterBoolean PipelineParameterType = "Boolean"
+	PipelineParameterChoice  PipelineParameterType = "Choice"
+)
+
+// A PipelineParameter is a parameter of a Pipeline job.
+type PipelineParameter struct {
+	// Name of the parameter.
+	Name string `json:"name"`
+
+	// Type of the parameter.
+	// +optional
+	// +kubebuilder:validation:Enum=String;Text;Boolean;Choice
+	// +kubebuilder:default=String
+	Type PipelineParameterType `json:"type,omitempty"`
+
+	// Description of the parameter.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// DefaultValue of a String, Text or Boolean parameter. The default value
+	// of a Boolean parameter is "true" or "false".
+	// +optional
+	DefaultValue string `json:"defaultValue,omitempty"`
+
+	// Choices of a Choice parameter. The first choice is the default.
+	// +optional
+	Choices []string `json:"choices,omitempty"`
+}
+
+// PipelineTriggers start a Pipeline job automatically.
+type PipelineTriggers struct {
+	// Cron builds the job periodically, e.g. "H 2 * * *".
+	// +optional
+	Cron string `json:"cron,omitempty"`
+
+	// PollSCM polls the repository of the job for changes periodically,
+	// e.g. "H/5 * * * *".
+	// +optional
+	PollSCM string `json:"pollSCM,omitempty"`
+
+	// Upstream builds the job after other jobs are built.
+	// +optional
+	Upstream *UpstreamTrigger `json:"upstream,omitempty"`
+}
+
+// An UpstreamThreshold is the worst result of an upstream build that
+// triggers a downstream job.
+type UpstreamThreshold string
+
+// Upstream thresholds.
+const (
+	UpstreamThresholdSuccess  UpstreamThreshold = "Success"
+	UpstreamThresholdUnstable UpstreamThreshold = "Unstable"
+	UpstreamThresholdFailure  UpstreamThreshold = "Failure"
+)
+
+// An UpstreamTrigger builds a job after other jobs are built.
+type UpstreamTrigger struct {
+	// Projects are the full names of the upstream jobs.
+	Projects []string `json:"projects"`
+
+	// Threshold is the worst result of an upstream build that triggers the
+	// job.
+	// +optional
+	// +kubebuilder:validation:Enum=Success;Unstable;Failure
+	// +kubebuilder:default=Success
+	Threshold UpstreamThreshold `json:"threshold,omitempty"`
+}
+
+// A BuildDiscarder limits how many builds and artifacts of a job are kept.
+// Limits that are omitted are not enforced.
+type BuildDiscarder struct {
+	// DaysToKeep builds for.
+	// +optional
+	DaysToKeep *int64 `json:"daysToKeep,omitempty"`
+
+	// NumToKeep is the number of builds to keep.
+	// +optional
+	NumToKeep *int64 `json:"numToKeep,omitempty"`
+
+	// ArtifactDaysToKeep artifacts for.
+	// +optional
+	ArtifactDaysToKeep *int64 `json:"artifactDaysToKeep,omitempty"`
+
+	// ArtifactNumToKeep is the number of builds to keep artifacts of.
+	// +optional
+	ArtifactNumToKeep *int64 `json:"artifactNumToKeep,omitempty"`
 }
 
 // JobObservation are the observable fields of a Job.
 type JobObservation struct {
 	Name string `json:"name"`
+
+	// FullName of the job, including its parent folders.
+	FullName string `json:"fullName,omitempty"`
+
+	// URL of the job.
+	URL string `json:"url,omitempty"`
+
+	// Buildable is false if the job cannot be built, e.g. because it is
+	// disabled.
+	Buildable bool `json:"buildable,omitempty"`
+
+	// Disabled is true if the job has been disabled.
+	Disabled bool `json:"disabled,omitempty"`
+
+	// Color is the status ball of the job as reported by Jenkins, e.g.
+	// "blue", "red" or "blue_anime" while building.
+	Color string `json:"color,omitempty"`
+
+	// HealthScore is the lowest score of the health reports of the job,
+	// from 0 to 100.
+	HealthScore *int64 `json:"healthScore,omitempty"`
+
+	// LastBuild is the most recent build of the job.
+	LastBuild *BuildObservation `json:"lastBuild,omitempty"`
+
+	// LastSuccessfulBuild is the most recent successful build of the job.
+	LastSuccessfulBuild *BuildObservation `json:"lastSuccessfulBuild,omitempty"`
+
+	// LastFailedBuild is the most recent failed build of the job.
+	LastFailedBuild *BuildObservation `json:"lastFailedBuild,omitempty"`
+}
+
+// A BuildObservation describes a build of a Job.
+type BuildObservation struct {
+	// Number of the build.
+	Number int64 `json:"number"`
+
+	// Result of the build, e.g. SUCCESS or FAILURE. It is empty while the
+	// build is running.
+	Result string `json:"result,omitempty"`
+
+	// Timestamp at which the build started.
+	Timestamp *metav1.Time `json:"timestamp,omitempty"`
 }
 
 // A JobSpec defines the desired state of a Job.
@@ -55,6 +344,7 @@ type JobStatus struct {
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="LAST-RESULT",type="string",JSONPath=".status.atProvider.lastBuild.result"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,jenkins}
@@ -0,0 +1,443 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// AnnotationKeyRescan triggers a scan of a MultibranchPipeline or an
+// OrganizationFolder whenever its value changes, e.g. to a timestamp.
+const AnnotationKeyRescan = "jenkins.crossplane.io/rescan"
+
+// A BranchDiscoveryStrategy determines which branches are discovered.
+type BranchDiscoveryStrategy string
+
+// Branch discovery strategies.
+const (
+	// BranchDiscoveryExcludePullRequests discovers branches that are not
+	// also filed as pull requests.
+	BranchDiscoveryExcludePullRequests BranchDiscoveryStrategy = "ExcludePullRequests"
+	// BranchDiscoveryOnlyPullRequests discovers only branches that are also
+	// filed as pull requests.
+	BranchDiscoveryOnlyPullRequests BranchDiscoveryStrategy = "OnlyPullRequests"
+	// BranchDiscoveryAll discovers all branches.
+	BranchDiscoveryAll BranchDiscoveryStrategy = "All"
+)
+
+// A PullRequestDiscoveryStrategy determines which revisions of discovered
+// pull requests are built.
+type PullRequestDiscoveryStrategy string
+
+// Pull request discovery strategies.
+const (
+	// PullRequestDiscoveryMerge builds the pull request merged with its
+	// target branch.
+	PullRequestDiscoveryMerge PullRequestDiscoveryStrategy = "Merge"
+	// PullRequestDiscoveryHead builds the head of the pull request.
+	PullRequestDiscoveryHead PullRequestDiscoveryStrategy = "Head"
+	// PullRequestDiscoveryBoth builds both the merged and the head revision
+	// of the pull request.
+	PullRequestDiscoveryBoth PullRequestDiscoveryStrategy = "Both"
+)
+
+// A ForkPullRequestTrust determines whose changes to the Jenkinsfile of pull
+// requests from forks are trusted.
+type ForkPullRequestTrust string
+
+// Fork pull request trust policies.
+const (
+	// ForkPullRequestTrustNobody uses the Jenkinsfile of the target branch
+	// for all pull requests from forks.
+	ForkPullRequestTrustNobody ForkPullRequestTrust = "Nobody"
+	// ForkPullRequestTrustContributors trusts pull requests from forks by
+	// collaborators of the origin repository, or members of the team on
+	// Bitbucket.
+	ForkPullRequestTrustContributors ForkPullRequestTrust = "Contributors"
+	// ForkPullRequestTrustEveryone trusts all pull requests from forks.
+	ForkPullRequestTrustEveryone ForkPullRequestTrust = "Everyone"
+	// ForkPullRequestTrustPermission trusts pull requests from forks by users
+	// with write permission to the origin repository. Supported by GitHub
+	// only.
+	ForkPullRequestTrustPermission ForkPullRequestTrust = "Permission"
+)
+
+// BranchDiscovery discovers the branches of a repository.
+type BranchDiscovery struct {
+	// Strategy determines which branches are discovered. It is ignored by
+	// Git sources, which discover all branches.
+	// +optional
+	// +kubebuilder:default=ExcludePullRequests
+	// +kubebuilder:validation:Enum=ExcludePullRequests;OnlyPullRequests;All
+	Strategy BranchDiscoveryStrategy `json:"strategy,omitempty"`
+}
+
+// PullRequestDiscovery discovers the pull requests filed from the origin
+// repository.
+type PullRequestDiscovery struct {
+	// Strategy determines which revisions of pull requests are built.
+	// +optional
+	// +kubebuilder:default=Merge
+	// +kubebuilder:validation:Enum=Merge;Head;Both
+	Strategy PullRequestDiscoveryStrategy `json:"strategy,omitempty"`
+}
+
+// ForkPullRequestDiscovery discovers the pull requests filed from forks of
+// the origin repository.
+type ForkPullRequestDiscovery struct {
+	// Strategy determines which revisions of pull requests are built.
+	// +optional
+	// +kubebuilder:default=Merge
+	// +kubebuilder:validation:Enum=Merge;Head;Both
+	Strategy PullRequestDiscoveryStrategy `json:"strategy,omitempty"`
+
+	// Trust determines whose changes to the Jenkinsfile are trusted.
+	// +optional
+	// +kubebuilder:default=Contributors
+	// +kubebuilder:validation:Enum=Nobody;Contributors;Everyone;Permission
+	Trust ForkPullRequestTrust `json:"trust,omitempty"`
+}
+
+// A WildcardFilter filters names by space separated wildcard patterns, e.g.
+// "main release-*".
+type WildcardFilter struct {
+	// Includes are the patterns of the names to include.
+	// +optional
+	// +kubebuilder:default="*"
+	Includes string `json:"includes,omitempty"`
+
+	// Excludes are the patterns of the names to exclude, even if they are
+	// included.
+	// +optional
+	Excludes string `json:"excludes,omitempty"`
+}
+
+// SCMTraits configure what a branch source or organization discovers.
+type SCMTraits struct {
+	// Branches discovers branches.
+	// +optional
+	Branches *BranchDiscovery `json:"branches,omitempty"`
+
+	// OriginPullRequests discovers pull requests filed from the origin
+	// repository. Not supported by Git sources.
+	// +optional
+	OriginPullRequests *PullRequestDiscovery `json:"originPullRequests,omitempty"`
+
+	// ForkPullRequests discovers pull requests filed from forks. Not
+	// supported by Git sources.
+	// +optional
+	ForkPullRequests *ForkPullRequestDiscovery `json:"forkPullRequests,omitempty"`
+
+	// Tags discovers tags.
+	// +optional
+	Tags bool `json:"tags,omitempty"`
+
+	// HeadFilter filters the discovered branches, pull requests and tags by
+	// name. Pull requests are named "PR-<number>".
+	// +optional
+	HeadFilter *WildcardFilter `json:"headFilter,omitempty"`
+}
+
+// A GitBranchSource discovers the branches of a plain Git repository.
+type GitBranchSource struct {
+	// Remote is the URL of the repository.
+	Remote string `json:"remote"`
+
+	// CredentialsID is the ID of the Jenkins credentials used to clone the
+	// repository.
+	// +optional
+	CredentialsID string `json:"credentialsId,omitempty"`
+
+	// Traits configure what is discovered. Only branches are discovered if
+	// Traits is omitted.
+	// +optional
+	Traits *SCMTraits `json:"traits,omitempty"`
+}
+
+// A GitHubBranchSource discovers the branches and pull requests of a GitHub
+// repository.
+type GitHubBranchSource struct {
+	// APIURI is the API endpoint of GitHub Enterprise, e.g.
+	// "https://github.example.com/api/v3". GitHub.com is used if it is
+	// empty.
+	// +optional
+	APIURI string `json:"apiUri,omitempty"`
+
+	// RepoOwner is the user or organization owning the repository.
+	RepoOwner string `json:"repoOwner"`
+
+	// Repository is the name of the repository.
+	Repository string `json:"repository"`
+
+	// CredentialsID is the ID of the Jenkins credentials used to access the
+	// repository, usually a username with a personal access token or a
+	// GitHub App.
+	// +optional
+	CredentialsID string `json:"credentialsId,omitempty"`
+
+	// Traits configure what is discovered. Only branches are discovered if
+	// Traits is omitted.
+	// +optional
+	Traits *SCMTraits `json:"traits,omitempty"`
+}
+
+// A BitbucketBranchSource discovers the branches and pull requests of a
+// Bitbucket repository.
+type BitbucketBranchSource struct {
+	// ServerURL is the URL of Bitbucket Server, or of Bitbucket Cloud.
+	// +optional
+	// +kubebuilder:default="https://bitbucket.org"
+	ServerURL string `json:"serverUrl,omitempty"`
+
+	// RepoOwner is the workspace, project or user owning the repository.
+	RepoOwner string `json:"repoOwner"`
+
+	// Repository is the name of the repository.
+	Repository string `json:"repository"`
+
+	// CredentialsID is the ID of the Jenkins credentials used to access the
+	// repository.
+	// +optional
+	CredentialsID string `json:"credentialsId,omitempty"`
+
+	// Traits configure what is discovered. Only branches are discovered if
+	// Traits is omitted.
+	// +optional
+	Traits *SCMTraits `json:"traits,omitempty"`
+}
+
+// A GiteaBranchSource discovers the branches and pull requests of a Gitea
+// repository.
+type GiteaBranchSource struct {
+	// ServerURL is the URL of the Gitea server. It must be configured in the
+	// global Gitea settings of Jenkins.
+	ServerURL string `json:"serverUrl"`
+
+	// RepoOwner is the user or organization owning the repository.
+	RepoOwner string `json:"repoOwner"`
+
+	// Repository is the name of the repository.
+	Repository string `json:"repository"`
+
+	// CredentialsID is the ID of the Jenkins credentials used to access the
+	// repository.
+	// +optional
+	CredentialsID string `json:"credentialsId,omitempty"`
+
+	// Traits configure what is discovered. Only branches are discovered if
+	// Traits is omitted.
+	// +optional
+	Traits *SCMTraits `json:"traits,omitempty"`
+}
+
+// A BranchSource is a repository whose branches are built by a
+// MultibranchPipeline. Exactly one of Git, GitHub, Bitbucket and Gitea must be
+// specified.
+type BranchSource struct {
+	// ID identifies the source in Jenkins. Changing it discards the build
+	// history of all branches of the source. Defaults to the index of the
+	// source.
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// Git is a plain Git repository.
+	// +optional
+	Git *GitBranchSource `json:"git,omitempty"`
+
+	// GitHub is a GitHub repository.
+	// +optional
+	GitHub *GitHubBranchSource `json:"github,omitempty"`
+
+	// Bitbucket is a Bitbucket repository.
+	// +optional
+	Bitbucket *BitbucketBranchSource `json:"bitbucket,omitempty"`
+
+	// Gitea is a Gitea repository.
+	// +optional
+	Gitea *GiteaBranchSource `json:"gitea,omitempty"`
+}
+
+// An OrphanedItemStrategy determines what happens to the items of branches,
+// pull requests or repositories that are no longer discovered.
+type OrphanedItemStrategy struct {
+	// DiscardOldItems deletes orphaned items. They are kept forever if it is
+	// false.
+	// +optional
+	// +kubebuilder:default=true
+	DiscardOldItems *bool `json:"discardOldItems,omitempty"`
+
+	// DaysToKeep is the number of days orphaned items are kept. They are
+	// kept regardless of age if it is omitted.
+	// +optional
+	DaysToKeep *int64 `json:"daysToKeep,omitempty"`
+
+	// NumToKeep is the number of orphaned items that are kept. They are kept
+	// regardless of their number if it is omitted.
+	// +optional
+	NumToKeep *int64 `json:"numToKeep,omitempty"`
+
+	// AbortBuilds aborts the running builds of orphaned items.
+	// +optional
+	AbortBuilds bool `json:"abortBuilds,omitempty"`
+}
+
+// ScanObservation is the result of the last scan of a MultibranchPipeline or
+// an OrganizationFolder.
+type ScanObservation struct {
+	// Result of the scan, e.g. SUCCESS or FAILURE. It is empty while the scan
+	// is running.
+	Result string `json:"result,omitempty"`
+
+	// Timestamp is the time the scan started.
+	Timestamp *metav1.Time `json:"timestamp,omitempty"`
+}
+
+// MultibranchPipelineParameters are the configurable fields of a
+// MultibranchPipeline.
+type MultibranchPipelineParameters struct {
+	// Name of the multibranch pipeline.
+	Name string `json:"name"`
+
+	// Parent is the full name of the folder containing the multibranch
+	// pipeline, e.g. "team/sub". It is created at the root of Jenkins if
+	// Parent is empty.
+	// +optional
+	// +crossplane:generate:reference:type=Folder
+	Parent string `json:"parent,omitempty"`
+
+	// ParentRef references the Folder containing the multibranch pipeline.
+	// The full name of the folder is taken from its external name.
+	// +optional
+	ParentRef *xpv1.Reference `json:"parentRef,omitempty"`
+
+	// ParentSelector selects a reference to the Folder containing the
+	// multibranch pipeline.
+	// +optional
+	ParentSelector *xpv1.Selector `json:"parentSelector,omitempty"`
+
+	// DisplayName of the multibranch pipeline. The name is displayed if it
+	// is empty.
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+
+	// Description of the multibranch pipeline.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Sources are the repositories whose branches are built.
+	// +kubebuilder:validation:MinItems=1
+	Sources []BranchSource `json:"sources"`
+
+	// ScriptPath is the path of the Jenkinsfile in the repositories.
+	// +optional
+	// +kubebuilder:default=Jenkinsfile
+	ScriptPath string `json:"scriptPath,omitempty"`
+
+	// OrphanedItemStrategy determines what happens to the jobs of branches
+	// that are no longer discovered.
+	// +optional
+	OrphanedItemStrategy *OrphanedItemStrategy `json:"orphanedItemStrategy,omitempty"`
+
+	// ScanInterval is the maximum time between scans of the sources. The
+	// sources are only scanned when notified by webhooks, or when triggered
+	// through the jenkins.crossplane.io/rescan annotation, if it is omitted.
+	// +optional
+	// +kubebuilder:validation:Enum="1m";"2m";"5m";"10m";"15m";"20m";"25m";"30m";"1h";"2h";"4h";"8h";"12h";"1d";"2d";"1w";"2w";"4w"
+	ScanInterval string `json:"scanInterval,omitempty"`
+
+	// Disabled prevents new builds of all branches.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// MultibranchPipelineObservation are the observable fields of a
+// MultibranchPipeline.
+type MultibranchPipelineObservation struct {
+	// FullName of the multibranch pipeline, including its parent folders.
+	FullName string `json:"fullName,omitempty"`
+
+	// URL of the multibranch pipeline.
+	URL string `json:"url,omitempty"`
+
+	// Branches are the names of the jobs of the discovered branches, pull
+	// requests and tags.
+	Branches []string `json:"branches,omitempty"`
+
+	// LastScan is the last scan of the sources.
+	LastScan *ScanObservation `json:"lastScan,omitempty"`
+}
+
+// A MultibranchPipelineSpec defines the desired state of a
+// MultibranchPipeline.
+type MultibranchPipelineSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       MultibranchPipelineParameters `json:"forProvider"`
+}
+
+// A MultibranchPipelineStatus represents the observed state of a
+// MultibranchPipeline.
+type MultibranchPipelineStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          MultibranchPipelineObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A MultibranchPipeline is a Jenkins multibranch pipeline that creates a
+// pipeline job for each branch, pull request and tag discovered in its
+// sources. Setting the jenkins.crossplane.io/rescan annotation to a new value
+// scans the sources.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="LAST-SCAN",type="string",JSONPath=".status.atProvider.lastScan.result"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,jenkins}
+type MultibranchPipeline struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MultibranchPipelineSpec   `json:"spec"`
+	Status MultibranchPipelineStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MultibranchPipelineList contains a list of MultibranchPipeline
+type MultibranchPipelineList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MultibranchPipeline `json:"items"`
+}
+
+// MultibranchPipeline type metadata.
+var (
+	MultibranchPipelineKind             = reflect.TypeOf(MultibranchPipeline{}).Name()
+	MultibranchPipelineGroupKind        = schema.GroupKind{Group: Group, Kind: MultibranchPipelineKind}.String()
+	MultibranchPipelineKindAPIVersion   = MultibranchPipelineKind + "." + SchemeGroupVersion.String()
+	MultibranchPipelineGroupVersionKind = SchemeGroupVersion.WithKind(MultibranchPipelineKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&MultibranchPipeline{}, &MultibranchPipelineList{})
+}
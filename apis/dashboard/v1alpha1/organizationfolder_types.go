@@ -0,0 +1,250 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// A GitHubNavigator discovers the repositories of a GitHub user or
+// organization.
+type GitHubNavigator struct {
+	// APIURI is the API endpoint of GitHub Enterprise, e.g.
+	// "https://github.example.com/api/v3". GitHub.com is used if it is
+	// empty.
+	// +optional
+	APIURI string `json:"apiUri,omitempty"`
+
+	// RepoOwner is the user or organization owning the repositories.
+	RepoOwner string `json:"repoOwner"`
+
+	// CredentialsID is the ID of the Jenkins credentials used to access the
+	// repositories, usually a username with a personal access token or a
+	// GitHub App.
+	// +optional
+	CredentialsID string `json:"credentialsId,omitempty"`
+
+	// RepositoryFilter filters the discovered repositories by name.
+	// +optional
+	RepositoryFilter *WildcardFilter `json:"repositoryFilter,omitempty"`
+
+	// Traits configure what is discovered in each repository. Only branches
+	// are discovered if Traits is omitted.
+	// +optional
+	Traits *SCMTraits `json:"traits,omitempty"`
+}
+
+// A BitbucketNavigator discovers the repositories of a Bitbucket workspace,
+// project or user.
+type BitbucketNavigator struct {
+	// ServerURL is the URL of Bitbucket Server, or of Bitbucket Cloud.
+	// +optional
+	// +kubebuilder:default="https://bitbucket.org"
+	ServerURL string `json:"serverUrl,omitempty"`
+
+	// RepoOwner is the workspace, project or user owning the repositories.
+	RepoOwner string `json:"repoOwner"`
+
+	// CredentialsID is the ID of the Jenkins credentials used to access the
+	// repositories.
+	// +optional
+	CredentialsID string `json:"credentialsId,omitempty"`
+
+	// RepositoryFilter filters the discovered repositories by name.
+	// +optional
+	RepositoryFilter *WildcardFilter `json:"repositoryFilter,omitempty"`
+
+	// Traits configure what is discovered in each repository. Only branches
+	// are discovered if Traits is omitted.
+	// +optional
+	Traits *SCMTraits `json:"traits,omitempty"`
+}
+
+// A GiteaNavigator discovers the repositories of a Gitea user or
+// organization.
+type GiteaNavigator struct {
+	// ServerURL is the URL of the Gitea server. It must be configured in the
+	// global Gitea settings of Jenkins.
+	ServerURL string `json:"serverUrl"`
+
+	// RepoOwner is the user or organization owning the repositories.
+	RepoOwner string `json:"repoOwner"`
+
+	// CredentialsID is the ID of the Jenkins credentials used to access the
+	// repositories.
+	// +optional
+	CredentialsID string `json:"credentialsId,omitempty"`
+
+	// RepositoryFilter filters the discovered repositories by name.
+	// +optional
+	RepositoryFilter *WildcardFilter `json:"repositoryFilter,omitempty"`
+
+	// Traits configure what is discovered in each repository. Only branches
+	// are discovered if Traits is omitted.
+	// +optional
+	Traits *SCMTraits `json:"traits,omitempty"`
+}
+
+// An SCMNavigator discovers the repositories scanned by an
+// OrganizationFolder. Exactly one of GitHub, Bitbucket and Gitea must be
+// specified. Plain Git servers cannot be navigated.
+type SCMNavigator struct {
+	// GitHub is a GitHub user or organization.
+	// +optional
+	GitHub *GitHubNavigator `json:"github,omitempty"`
+
+	// Bitbucket is a Bitbucket workspace, project or user.
+	// +optional
+	Bitbucket *BitbucketNavigator `json:"bitbucket,omitempty"`
+
+	// Gitea is a Gitea user or organization.
+	// +optional
+	Gitea *GiteaNavigator `json:"gitea,omitempty"`
+}
+
+// OrganizationFolderParameters are the configurable fields of an
+// OrganizationFolder.
+type OrganizationFolderParameters struct {
+	// Name of the organization folder.
+	Name string `json:"name"`
+
+	// Parent is the full name of the folder containing the organization
+	// folder, e.g. "team/sub". It is created at the root of Jenkins if
+	// Parent is empty.
+	// +optional
+	// +crossplane:generate:reference:type=Folder
+	Parent string `json:"parent,omitempty"`
+
+	// ParentRef references the Folder containing the organization folder.
+	// The full name of the folder is taken from its external name.
+	// +optional
+	ParentRef *xpv1.Reference `json:"parentRef,omitempty"`
+
+	// ParentSelector selects a reference to the Folder containing the
+	// organization folder.
+	// +optional
+	ParentSelector *xpv1.Selector `json:"parentSelector,omitempty"`
+
+	// DisplayName of the organization folder. The name is displayed if it is
+	// empty.
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+
+	// Description of the organization folder.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Navigators discover the repositories containing a Jenkinsfile.
+	// +kubebuilder:validation:MinItems=1
+	Navigators []SCMNavigator `json:"navigators"`
+
+	// ScriptPath is the path of the Jenkinsfile in the repositories.
+	// Repositories without it are not built.
+	// +optional
+	// +kubebuilder:default=Jenkinsfile
+	ScriptPath string `json:"scriptPath,omitempty"`
+
+	// OrphanedItemStrategy determines what happens to the multibranch
+	// pipelines of repositories that are no longer discovered.
+	// +optional
+	OrphanedItemStrategy *OrphanedItemStrategy `json:"orphanedItemStrategy,omitempty"`
+
+	// ScanInterval is the maximum time between scans of the organization.
+	// It is only scanned when notified by webhooks, or when triggered
+	// through the jenkins.crossplane.io/rescan annotation, if it is omitted.
+	// +optional
+	// +kubebuilder:validation:Enum="1m";"2m";"5m";"10m";"15m";"20m";"25m";"30m";"1h";"2h";"4h";"8h";"12h";"1d";"2d";"1w";"2w";"4w"
+	ScanInterval string `json:"scanInterval,omitempty"`
+}
+
+// OrganizationFolderObservation are the observable fields of an
+// OrganizationFolder.
+type OrganizationFolderObservation struct {
+	// FullName of the organization folder, including its parent folders.
+	FullName string `json:"fullName,omitempty"`
+
+	// URL of the organization folder.
+	URL string `json:"url,omitempty"`
+
+	// Repositories are the names of the multibranch pipelines of the
+	// discovered repositories.
+	Repositories []string `json:"repositories,omitempty"`
+
+	// LastScan is the last scan of the organization.
+	LastScan *ScanObservation `json:"lastScan,omitempty"`
+}
+
+// An OrganizationFolderSpec defines the desired state of an
+// OrganizationFolder.
+type OrganizationFolderSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       OrganizationFolderParameters `json:"forProvider"`
+}
+
+// An OrganizationFolderStatus represents the observed state of an
+// OrganizationFolder.
+type OrganizationFolderStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          OrganizationFolderObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An OrganizationFolder is a Jenkins organization folder that creates a
+// multibranch pipeline for each repository containing a Jenkinsfile discovered
+// in an organization. Setting the jenkins.crossplane.io/rescan annotation to a
+// new value scans the organization.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="LAST-SCAN",type="string",JSONPath=".status.atProvider.lastScan.result"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,jenkins}
+type OrganizationFolder struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OrganizationFolderSpec   `json:"spec"`
+	Status OrganizationFolderStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OrganizationFolderList contains a list of OrganizationFolder
+type OrganizationFolderList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OrganizationFolder `json:"items"`
+}
+
+// OrganizationFolder type metadata.
+var (
+	OrganizationFolderKind             = reflect.TypeOf(OrganizationFolder{}).Name()
+	OrganizationFolderGroupKind        = schema.GroupKind{Group: Group, Kind: OrganizationFolderKind}.String()
+	OrganizationFolderKindAPIVersion   = OrganizationFolderKind + "." + SchemeGroupVersion.String()
+	OrganizationFolderGroupVersionKind = SchemeGroupVersion.WithKind(OrganizationFolderKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&OrganizationFolder{}, &OrganizationFolderList{})
+}
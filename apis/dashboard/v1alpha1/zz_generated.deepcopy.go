@@ -22,11 +22,227 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"github.com/crossplane/crossplane-runtime/apis/common/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JenkinsNode) DeepCopyInto(out *JenkinsNode) {
+func (in *AlwaysRetentionStrategy) DeepCopyInto(out *AlwaysRetentionStrategy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlwaysRetentionStrategy.
+func (in *AlwaysRetentionStrategy) DeepCopy() *AlwaysRetentionStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(AlwaysRetentionStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BitbucketBranchSource) DeepCopyInto(out *BitbucketBranchSource) {
+	*out = *in
+	if in.Traits != nil {
+		in, out := &in.Traits, &out.Traits
+		*out = new(SCMTraits)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BitbucketBranchSource.
+func (in *BitbucketBranchSource) DeepCopy() *BitbucketBranchSource {
+	if in == nil {
+		return nil
+	}
+	out := new(BitbucketBranchSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BitbucketNavigator) DeepCopyInto(out *BitbucketNavigator) {
+	*out = *in
+	if in.RepositoryFilter != nil {
+		in, out := &in.RepositoryFilter, &out.RepositoryFilter
+		*out = new(WildcardFilter)
+		**out = **in
+	}
+	if in.Traits != nil {
+		in, out := &in.Traits, &out.Traits
+		*out = new(SCMTraits)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BitbucketNavigator.
+func (in *BitbucketNavigator) DeepCopy() *BitbucketNavigator {
+	if in == nil {
+		return nil
+	}
+	out := new(BitbucketNavigator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BranchDiscovery) DeepCopyInto(out *BranchDiscovery) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BranchDiscovery.
+func (in *BranchDiscovery) DeepCopy() *BranchDiscovery {
+	if in == nil {
+		return nil
+	}
+	out := new(BranchDiscovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BranchSource) DeepCopyInto(out *BranchSource) {
+	*out = *in
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(GitBranchSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GitHub != nil {
+		in, out := &in.GitHub, &out.GitHub
+		*out = new(GitHubBranchSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bitbucket != nil {
+		in, out := &in.Bitbucket, &out.Bitbucket
+		*out = new(BitbucketBranchSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Gitea != nil {
+		in, out := &in.Gitea, &out.Gitea
+		*out = new(GiteaBranchSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BranchSource.
+func (in *BranchSource) DeepCopy() *BranchSource {
+	if in == nil {
+		return nil
+	}
+	out := new(BranchSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildDiscarder) DeepCopyInto(out *BuildDiscarder) {
+	*out = *in
+	if in.DaysToKeep != nil {
+		in, out := &in.DaysToKeep, &out.DaysToKeep
+		*out = new(int64)
+		**out = **in
+	}
+	if in.NumToKeep != nil {
+		in, out := &in.NumToKeep, &out.NumToKeep
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ArtifactDaysToKeep != nil {
+		in, out := &in.ArtifactDaysToKeep, &out.ArtifactDaysToKeep
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ArtifactNumToKeep != nil {
+		in, out := &in.ArtifactNumToKeep, &out.ArtifactNumToKeep
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildDiscarder.
+func (in *BuildDiscarder) DeepCopy() *BuildDiscarder {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildDiscarder)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildObservation) DeepCopyInto(out *BuildObservation) {
+	*out = *in
+	if in.Timestamp != nil {
+		in, out := &in.Timestamp, &out.Timestamp
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildObservation.
+func (in *BuildObservation) DeepCopy() *BuildObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateCredential) DeepCopyInto(out *CertificateCredential) {
+	*out = *in
+	out.KeyStoreSecretRef = in.KeyStoreSecretRef
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateCredential.
+func (in *CertificateCredential) DeepCopy() *CertificateCredential {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateCredential)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommandLauncher) DeepCopyInto(out *CommandLauncher) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommandLauncher.
+func (in *CommandLauncher) DeepCopy() *CommandLauncher {
+	if in == nil {
+		return nil
+	}
+	out := new(CommandLauncher)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapKeySelector) DeepCopyInto(out *ConfigMapKeySelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapKeySelector.
+func (in *ConfigMapKeySelector) DeepCopy() *ConfigMapKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Credential) DeepCopyInto(out *Credential) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -34,18 +250,18 @@ func (in *JenkinsNode) DeepCopyInto(out *JenkinsNode) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsNode.
-func (in *JenkinsNode) DeepCopy() *JenkinsNode {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Credential.
+func (in *Credential) DeepCopy() *Credential {
 	if in == nil {
 		return nil
 	}
-	out := new(JenkinsNode)
+	out := new(Credential)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *JenkinsNode) DeepCopyObject() runtime.Object {
+func (in *Credential) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -53,31 +269,31 @@ func (in *JenkinsNode) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JenkinsNodeList) DeepCopyInto(out *JenkinsNodeList) {
+func (in *CredentialList) DeepCopyInto(out *CredentialList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]JenkinsNode, len(*in))
+		*out = make([]Credential, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsNodeList.
-func (in *JenkinsNodeList) DeepCopy() *JenkinsNodeList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialList.
+func (in *CredentialList) DeepCopy() *CredentialList {
 	if in == nil {
 		return nil
 	}
-	out := new(JenkinsNodeList)
+	out := new(CredentialList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *JenkinsNodeList) DeepCopyObject() runtime.Object {
+func (in *CredentialList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -85,71 +301,152 @@ func (in *JenkinsNodeList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JenkinsNodeObservation) DeepCopyInto(out *JenkinsNodeObservation) {
+func (in *CredentialObservation) DeepCopyInto(out *CredentialObservation) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsNodeObservation.
-func (in *JenkinsNodeObservation) DeepCopy() *JenkinsNodeObservation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialObservation.
+func (in *CredentialObservation) DeepCopy() *CredentialObservation {
 	if in == nil {
 		return nil
 	}
-	out := new(JenkinsNodeObservation)
+	out := new(CredentialObservation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JenkinsNodeParameters) DeepCopyInto(out *JenkinsNodeParameters) {
+func (in *CredentialParameters) DeepCopyInto(out *CredentialParameters) {
 	*out = *in
+	if in.FolderRef != nil {
+		in, out := &in.FolderRef, &out.FolderRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FolderSelector != nil {
+		in, out := &in.FolderSelector, &out.FolderSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UsernamePassword != nil {
+		in, out := &in.UsernamePassword, &out.UsernamePassword
+		*out = new(UsernamePasswordCredential)
+		**out = **in
+	}
+	if in.SecretText != nil {
+		in, out := &in.SecretText, &out.SecretText
+		*out = new(SecretTextCredential)
+		**out = **in
+	}
+	if in.SSHPrivateKey != nil {
+		in, out := &in.SSHPrivateKey, &out.SSHPrivateKey
+		*out = new(SSHPrivateKeyCredential)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Certificate != nil {
+		in, out := &in.Certificate, &out.Certificate
+		*out = new(CertificateCredential)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.File != nil {
+		in, out := &in.File, &out.File
+		*out = new(FileCredential)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsNodeParameters.
-func (in *JenkinsNodeParameters) DeepCopy() *JenkinsNodeParameters {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialParameters.
+func (in *CredentialParameters) DeepCopy() *CredentialParameters {
 	if in == nil {
 		return nil
 	}
-	out := new(JenkinsNodeParameters)
+	out := new(CredentialParameters)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JenkinsNodeSpec) DeepCopyInto(out *JenkinsNodeSpec) {
+func (in *CredentialSpec) DeepCopyInto(out *CredentialSpec) {
 	*out = *in
 	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
-	out.ForProvider = in.ForProvider
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsNodeSpec.
-func (in *JenkinsNodeSpec) DeepCopy() *JenkinsNodeSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialSpec.
+func (in *CredentialSpec) DeepCopy() *CredentialSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(JenkinsNodeSpec)
+	out := new(CredentialSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JenkinsNodeStatus) DeepCopyInto(out *JenkinsNodeStatus) {
+func (in *CredentialStatus) DeepCopyInto(out *CredentialStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
 	out.AtProvider = in.AtProvider
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsNodeStatus.
-func (in *JenkinsNodeStatus) DeepCopy() *JenkinsNodeStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialStatus.
+func (in *CredentialStatus) DeepCopy() *CredentialStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(JenkinsNodeStatus)
+	out := new(CredentialStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Job) DeepCopyInto(out *Job) {
+func (in *DiskSpaceMonitoringThresholds) DeepCopyInto(out *DiskSpaceMonitoringThresholds) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskSpaceMonitoringThresholds.
+func (in *DiskSpaceMonitoringThresholds) DeepCopy() *DiskSpaceMonitoringThresholds {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskSpaceMonitoringThresholds)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentVariable) DeepCopyInto(out *EnvironmentVariable) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentVariable.
+func (in *EnvironmentVariable) DeepCopy() *EnvironmentVariable {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentVariable)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileCredential) DeepCopyInto(out *FileCredential) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileCredential.
+func (in *FileCredential) DeepCopy() *FileCredential {
+	if in == nil {
+		return nil
+	}
+	out := new(FileCredential)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Folder) DeepCopyInto(out *Folder) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -157,18 +454,18 @@ func (in *Job) DeepCopyInto(out *Job) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Job.
-func (in *Job) DeepCopy() *Job {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Folder.
+func (in *Folder) DeepCopy() *Folder {
 	if in == nil {
 		return nil
 	}
-	out := new(Job)
+	out := new(Folder)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Job) DeepCopyObject() runtime.Object {
+func (in *Folder) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -176,31 +473,51 @@ func (in *Job) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JobList) DeepCopyInto(out *JobList) {
+func (in *FolderHealthMetric) DeepCopyInto(out *FolderHealthMetric) {
+	*out = *in
+	if in.Recursive != nil {
+		in, out := &in.Recursive, &out.Recursive
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderHealthMetric.
+func (in *FolderHealthMetric) DeepCopy() *FolderHealthMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(FolderHealthMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FolderList) DeepCopyInto(out *FolderList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Job, len(*in))
+		*out = make([]Folder, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobList.
-func (in *JobList) DeepCopy() *JobList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderList.
+func (in *FolderList) DeepCopy() *FolderList {
 	if in == nil {
 		return nil
 	}
-	out := new(JobList)
+	out := new(FolderList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *JobList) DeepCopyObject() runtime.Object {
+func (in *FolderList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -208,65 +525,1394 @@ func (in *JobList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JobObservation) DeepCopyInto(out *JobObservation) {
+func (in *FolderObservation) DeepCopyInto(out *FolderObservation) {
 	*out = *in
+	if in.HealthScore != nil {
+		in, out := &in.HealthScore, &out.HealthScore
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobObservation.
-func (in *JobObservation) DeepCopy() *JobObservation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderObservation.
+func (in *FolderObservation) DeepCopy() *FolderObservation {
 	if in == nil {
 		return nil
 	}
-	out := new(JobObservation)
+	out := new(FolderObservation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JobParameters) DeepCopyInto(out *JobParameters) {
+func (in *FolderParameters) DeepCopyInto(out *FolderParameters) {
 	*out = *in
+	if in.HealthMetrics != nil {
+		in, out := &in.HealthMetrics, &out.HealthMetrics
+		*out = make([]FolderHealthMetric, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobParameters.
-func (in *JobParameters) DeepCopy() *JobParameters {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderParameters.
+func (in *FolderParameters) DeepCopy() *FolderParameters {
 	if in == nil {
 		return nil
 	}
-	out := new(JobParameters)
+	out := new(FolderParameters)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JobSpec) DeepCopyInto(out *JobSpec) {
+func (in *FolderSpec) DeepCopyInto(out *FolderSpec) {
 	*out = *in
 	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
-	out.ForProvider = in.ForProvider
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobSpec.
-func (in *JobSpec) DeepCopy() *JobSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderSpec.
+func (in *FolderSpec) DeepCopy() *FolderSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(JobSpec)
+	out := new(FolderSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JobStatus) DeepCopyInto(out *JobStatus) {
+func (in *FolderStatus) DeepCopyInto(out *FolderStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
-	out.AtProvider = in.AtProvider
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobStatus.
-func (in *JobStatus) DeepCopy() *JobStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderStatus.
+func (in *FolderStatus) DeepCopy() *FolderStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(JobStatus)
+	out := new(FolderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForkPullRequestDiscovery) DeepCopyInto(out *ForkPullRequestDiscovery) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForkPullRequestDiscovery.
+func (in *ForkPullRequestDiscovery) DeepCopy() *ForkPullRequestDiscovery {
+	if in == nil {
+		return nil
+	}
+	out := new(ForkPullRequestDiscovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitBranchSource) DeepCopyInto(out *GitBranchSource) {
+	*out = *in
+	if in.Traits != nil {
+		in, out := &in.Traits, &out.Traits
+		*out = new(SCMTraits)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitBranchSource.
+func (in *GitBranchSource) DeepCopy() *GitBranchSource {
+	if in == nil {
+		return nil
+	}
+	out := new(GitBranchSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubBranchSource) DeepCopyInto(out *GitHubBranchSource) {
+	*out = *in
+	if in.Traits != nil {
+		in, out := &in.Traits, &out.Traits
+		*out = new(SCMTraits)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubBranchSource.
+func (in *GitHubBranchSource) DeepCopy() *GitHubBranchSource {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHubBranchSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubNavigator) DeepCopyInto(out *GitHubNavigator) {
+	*out = *in
+	if in.RepositoryFilter != nil {
+		in, out := &in.RepositoryFilter, &out.RepositoryFilter
+		*out = new(WildcardFilter)
+		**out = **in
+	}
+	if in.Traits != nil {
+		in, out := &in.Traits, &out.Traits
+		*out = new(SCMTraits)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubNavigator.
+func (in *GitHubNavigator) DeepCopy() *GitHubNavigator {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHubNavigator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GiteaBranchSource) DeepCopyInto(out *GiteaBranchSource) {
+	*out = *in
+	if in.Traits != nil {
+		in, out := &in.Traits, &out.Traits
+		*out = new(SCMTraits)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GiteaBranchSource.
+func (in *GiteaBranchSource) DeepCopy() *GiteaBranchSource {
+	if in == nil {
+		return nil
+	}
+	out := new(GiteaBranchSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GiteaNavigator) DeepCopyInto(out *GiteaNavigator) {
+	*out = *in
+	if in.RepositoryFilter != nil {
+		in, out := &in.RepositoryFilter, &out.RepositoryFilter
+		*out = new(WildcardFilter)
+		**out = **in
+	}
+	if in.Traits != nil {
+		in, out := &in.Traits, &out.Traits
+		*out = new(SCMTraits)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GiteaNavigator.
+func (in *GiteaNavigator) DeepCopy() *GiteaNavigator {
+	if in == nil {
+		return nil
+	}
+	out := new(GiteaNavigator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InboundLauncher) DeepCopyInto(out *InboundLauncher) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InboundLauncher.
+func (in *InboundLauncher) DeepCopy() *InboundLauncher {
+	if in == nil {
+		return nil
+	}
+	out := new(InboundLauncher)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsNode) DeepCopyInto(out *JenkinsNode) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsNode.
+func (in *JenkinsNode) DeepCopy() *JenkinsNode {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JenkinsNode) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsNodeLauncher) DeepCopyInto(out *JenkinsNodeLauncher) {
+	*out = *in
+	if in.Inbound != nil {
+		in, out := &in.Inbound, &out.Inbound
+		*out = new(InboundLauncher)
+		**out = **in
+	}
+	if in.SSH != nil {
+		in, out := &in.SSH, &out.SSH
+		*out = new(SSHLauncher)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = new(CommandLauncher)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsNodeLauncher.
+func (in *JenkinsNodeLauncher) DeepCopy() *JenkinsNodeLauncher {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsNodeLauncher)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsNodeList) DeepCopyInto(out *JenkinsNodeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]JenkinsNode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsNodeList.
+func (in *JenkinsNodeList) DeepCopy() *JenkinsNodeList {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsNodeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JenkinsNodeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsNodeObservation) DeepCopyInto(out *JenkinsNodeObservation) {
+	*out = *in
+	if in.FreeDiskSpaceBytes != nil {
+		in, out := &in.FreeDiskSpaceBytes, &out.FreeDiskSpaceBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ResponseTimeMillis != nil {
+		in, out := &in.ResponseTimeMillis, &out.ResponseTimeMillis
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsNodeObservation.
+func (in *JenkinsNodeObservation) DeepCopy() *JenkinsNodeObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsNodeObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsNodeParameters) DeepCopyInto(out *JenkinsNodeParameters) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Mode != nil {
+		in, out := &in.Mode, &out.Mode
+		*out = new(JenkinsNodeMode)
+		**out = **in
+	}
+	if in.RetentionStrategy != nil {
+		in, out := &in.RetentionStrategy, &out.RetentionStrategy
+		*out = new(RetentionStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeProperties != nil {
+		in, out := &in.NodeProperties, &out.NodeProperties
+		*out = new(JenkinsNodeProperties)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Launcher != nil {
+		in, out := &in.Launcher, &out.Launcher
+		*out = new(JenkinsNodeLauncher)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsNodeParameters.
+func (in *JenkinsNodeParameters) DeepCopy() *JenkinsNodeParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsNodeParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsNodeProperties) DeepCopyInto(out *JenkinsNodeProperties) {
+	*out = *in
+	if in.EnvironmentVariables != nil {
+		in, out := &in.EnvironmentVariables, &out.EnvironmentVariables
+		*out = make([]EnvironmentVariable, len(*in))
+		copy(*out, *in)
+	}
+	if in.ToolLocations != nil {
+		in, out := &in.ToolLocations, &out.ToolLocations
+		*out = make([]ToolLocation, len(*in))
+		copy(*out, *in)
+	}
+	if in.DiskSpaceMonitoring != nil {
+		in, out := &in.DiskSpaceMonitoring, &out.DiskSpaceMonitoring
+		*out = new(DiskSpaceMonitoringThresholds)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsNodeProperties.
+func (in *JenkinsNodeProperties) DeepCopy() *JenkinsNodeProperties {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsNodeProperties)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsNodeSpec) DeepCopyInto(out *JenkinsNodeSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsNodeSpec.
+func (in *JenkinsNodeSpec) DeepCopy() *JenkinsNodeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsNodeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsNodeStatus) DeepCopyInto(out *JenkinsNodeStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsNodeStatus.
+func (in *JenkinsNodeStatus) DeepCopy() *JenkinsNodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsNodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Job) DeepCopyInto(out *Job) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Job.
+func (in *Job) DeepCopy() *Job {
+	if in == nil {
+		return nil
+	}
+	out := new(Job)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Job) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobConfigSource) DeepCopyInto(out *JobConfigSource) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(ConfigMapKeySelector)
+		**out = **in
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobConfigSource.
+func (in *JobConfigSource) DeepCopy() *JobConfigSource {
+	if in == nil {
+		return nil
+	}
+	out := new(JobConfigSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobList) DeepCopyInto(out *JobList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Job, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobList.
+func (in *JobList) DeepCopy() *JobList {
+	if in == nil {
+		return nil
+	}
+	out := new(JobList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JobList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobObservation) DeepCopyInto(out *JobObservation) {
+	*out = *in
+	if in.HealthScore != nil {
+		in, out := &in.HealthScore, &out.HealthScore
+		*out = new(int64)
+		**out = **in
+	}
+	if in.LastBuild != nil {
+		in, out := &in.LastBuild, &out.LastBuild
+		*out = new(BuildObservation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastSuccessfulBuild != nil {
+		in, out := &in.LastSuccessfulBuild, &out.LastSuccessfulBuild
+		*out = new(BuildObservation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastFailedBuild != nil {
+		in, out := &in.LastFailedBuild, &out.LastFailedBuild
+		*out = new(BuildObservation)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobObservation.
+func (in *JobObservation) DeepCopy() *JobObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(JobObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobParameters) DeepCopyInto(out *JobParameters) {
+	*out = *in
+	if in.ParentRef != nil {
+		in, out := &in.ParentRef, &out.ParentRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ParentSelector != nil {
+		in, out := &in.ParentSelector, &out.ParentSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigFrom != nil {
+		in, out := &in.ConfigFrom, &out.ConfigFrom
+		*out = new(JobConfigSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pipeline != nil {
+		in, out := &in.Pipeline, &out.Pipeline
+		*out = new(Pipeline)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(JobTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TemplateVariables != nil {
+		in, out := &in.TemplateVariables, &out.TemplateVariables
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.IgnorePluginVersions != nil {
+		in, out := &in.IgnorePluginVersions, &out.IgnorePluginVersions
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobParameters.
+func (in *JobParameters) DeepCopy() *JobParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(JobParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobSpec) DeepCopyInto(out *JobSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobSpec.
+func (in *JobSpec) DeepCopy() *JobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobStatus) DeepCopyInto(out *JobStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobStatus.
+func (in *JobStatus) DeepCopy() *JobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(JobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobTemplate) DeepCopyInto(out *JobTemplate) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(ConfigMapKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobTemplate.
+func (in *JobTemplate) DeepCopy() *JobTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(JobTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultibranchPipeline) DeepCopyInto(out *MultibranchPipeline) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultibranchPipeline.
+func (in *MultibranchPipeline) DeepCopy() *MultibranchPipeline {
+	if in == nil {
+		return nil
+	}
+	out := new(MultibranchPipeline)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MultibranchPipeline) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultibranchPipelineList) DeepCopyInto(out *MultibranchPipelineList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MultibranchPipeline, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultibranchPipelineList.
+func (in *MultibranchPipelineList) DeepCopy() *MultibranchPipelineList {
+	if in == nil {
+		return nil
+	}
+	out := new(MultibranchPipelineList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MultibranchPipelineList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultibranchPipelineObservation) DeepCopyInto(out *MultibranchPipelineObservation) {
+	*out = *in
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastScan != nil {
+		in, out := &in.LastScan, &out.LastScan
+		*out = new(ScanObservation)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultibranchPipelineObservation.
+func (in *MultibranchPipelineObservation) DeepCopy() *MultibranchPipelineObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(MultibranchPipelineObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultibranchPipelineParameters) DeepCopyInto(out *MultibranchPipelineParameters) {
+	*out = *in
+	if in.ParentRef != nil {
+		in, out := &in.ParentRef, &out.ParentRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ParentSelector != nil {
+		in, out := &in.ParentSelector, &out.ParentSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]BranchSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OrphanedItemStrategy != nil {
+		in, out := &in.OrphanedItemStrategy, &out.OrphanedItemStrategy
+		*out = new(OrphanedItemStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultibranchPipelineParameters.
+func (in *MultibranchPipelineParameters) DeepCopy() *MultibranchPipelineParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(MultibranchPipelineParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultibranchPipelineSpec) DeepCopyInto(out *MultibranchPipelineSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultibranchPipelineSpec.
+func (in *MultibranchPipelineSpec) DeepCopy() *MultibranchPipelineSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MultibranchPipelineSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultibranchPipelineStatus) DeepCopyInto(out *MultibranchPipelineStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultibranchPipelineStatus.
+func (in *MultibranchPipelineStatus) DeepCopy() *MultibranchPipelineStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MultibranchPipelineStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OnDemandRetentionStrategy) DeepCopyInto(out *OnDemandRetentionStrategy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnDemandRetentionStrategy.
+func (in *OnDemandRetentionStrategy) DeepCopy() *OnDemandRetentionStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(OnDemandRetentionStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationFolder) DeepCopyInto(out *OrganizationFolder) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationFolder.
+func (in *OrganizationFolder) DeepCopy() *OrganizationFolder {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationFolder)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OrganizationFolder) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationFolderList) DeepCopyInto(out *OrganizationFolderList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OrganizationFolder, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationFolderList.
+func (in *OrganizationFolderList) DeepCopy() *OrganizationFolderList {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationFolderList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OrganizationFolderList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationFolderObservation) DeepCopyInto(out *OrganizationFolderObservation) {
+	*out = *in
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastScan != nil {
+		in, out := &in.LastScan, &out.LastScan
+		*out = new(ScanObservation)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationFolderObservation.
+func (in *OrganizationFolderObservation) DeepCopy() *OrganizationFolderObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationFolderObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationFolderParameters) DeepCopyInto(out *OrganizationFolderParameters) {
+	*out = *in
+	if in.ParentRef != nil {
+		in, out := &in.ParentRef, &out.ParentRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ParentSelector != nil {
+		in, out := &in.ParentSelector, &out.ParentSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Navigators != nil {
+		in, out := &in.Navigators, &out.Navigators
+		*out = make([]SCMNavigator, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OrphanedItemStrategy != nil {
+		in, out := &in.OrphanedItemStrategy, &out.OrphanedItemStrategy
+		*out = new(OrphanedItemStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationFolderParameters.
+func (in *OrganizationFolderParameters) DeepCopy() *OrganizationFolderParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationFolderParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationFolderSpec) DeepCopyInto(out *OrganizationFolderSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationFolderSpec.
+func (in *OrganizationFolderSpec) DeepCopy() *OrganizationFolderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationFolderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationFolderStatus) DeepCopyInto(out *OrganizationFolderStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationFolderStatus.
+func (in *OrganizationFolderStatus) DeepCopy() *OrganizationFolderStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationFolderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrphanedItemStrategy) DeepCopyInto(out *OrphanedItemStrategy) {
+	*out = *in
+	if in.DiscardOldItems != nil {
+		in, out := &in.DiscardOldItems, &out.DiscardOldItems
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DaysToKeep != nil {
+		in, out := &in.DaysToKeep, &out.DaysToKeep
+		*out = new(int64)
+		**out = **in
+	}
+	if in.NumToKeep != nil {
+		in, out := &in.NumToKeep, &out.NumToKeep
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrphanedItemStrategy.
+func (in *OrphanedItemStrategy) DeepCopy() *OrphanedItemStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(OrphanedItemStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Pipeline) DeepCopyInto(out *Pipeline) {
+	*out = *in
+	if in.Sandbox != nil {
+		in, out := &in.Sandbox, &out.Sandbox
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(PipelineGitSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]PipelineParameter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = new(PipelineTriggers)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BuildDiscarder != nil {
+		in, out := &in.BuildDiscarder, &out.BuildDiscarder
+		*out = new(BuildDiscarder)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pipeline.
+func (in *Pipeline) DeepCopy() *Pipeline {
+	if in == nil {
+		return nil
+	}
+	out := new(Pipeline)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineGitSource) DeepCopyInto(out *PipelineGitSource) {
+	*out = *in
+	if in.Lightweight != nil {
+		in, out := &in.Lightweight, &out.Lightweight
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineGitSource.
+func (in *PipelineGitSource) DeepCopy() *PipelineGitSource {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineGitSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineParameter) DeepCopyInto(out *PipelineParameter) {
+	*out = *in
+	if in.Choices != nil {
+		in, out := &in.Choices, &out.Choices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineParameter.
+func (in *PipelineParameter) DeepCopy() *PipelineParameter {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTriggers) DeepCopyInto(out *PipelineTriggers) {
+	*out = *in
+	if in.Upstream != nil {
+		in, out := &in.Upstream, &out.Upstream
+		*out = new(UpstreamTrigger)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTriggers.
+func (in *PipelineTriggers) DeepCopy() *PipelineTriggers {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTriggers)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PullRequestDiscovery) DeepCopyInto(out *PullRequestDiscovery) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PullRequestDiscovery.
+func (in *PullRequestDiscovery) DeepCopy() *PullRequestDiscovery {
+	if in == nil {
+		return nil
+	}
+	out := new(PullRequestDiscovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetentionStrategy) DeepCopyInto(out *RetentionStrategy) {
+	*out = *in
+	if in.Always != nil {
+		in, out := &in.Always, &out.Always
+		*out = new(AlwaysRetentionStrategy)
+		**out = **in
+	}
+	if in.OnDemand != nil {
+		in, out := &in.OnDemand, &out.OnDemand
+		*out = new(OnDemandRetentionStrategy)
+		**out = **in
+	}
+	if in.Scheduled != nil {
+		in, out := &in.Scheduled, &out.Scheduled
+		*out = new(ScheduledRetentionStrategy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetentionStrategy.
+func (in *RetentionStrategy) DeepCopy() *RetentionStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(RetentionStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SCMNavigator) DeepCopyInto(out *SCMNavigator) {
+	*out = *in
+	if in.GitHub != nil {
+		in, out := &in.GitHub, &out.GitHub
+		*out = new(GitHubNavigator)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bitbucket != nil {
+		in, out := &in.Bitbucket, &out.Bitbucket
+		*out = new(BitbucketNavigator)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Gitea != nil {
+		in, out := &in.Gitea, &out.Gitea
+		*out = new(GiteaNavigator)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SCMNavigator.
+func (in *SCMNavigator) DeepCopy() *SCMNavigator {
+	if in == nil {
+		return nil
+	}
+	out := new(SCMNavigator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SCMTraits) DeepCopyInto(out *SCMTraits) {
+	*out = *in
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = new(BranchDiscovery)
+		**out = **in
+	}
+	if in.OriginPullRequests != nil {
+		in, out := &in.OriginPullRequests, &out.OriginPullRequests
+		*out = new(PullRequestDiscovery)
+		**out = **in
+	}
+	if in.ForkPullRequests != nil {
+		in, out := &in.ForkPullRequests, &out.ForkPullRequests
+		*out = new(ForkPullRequestDiscovery)
+		**out = **in
+	}
+	if in.HeadFilter != nil {
+		in, out := &in.HeadFilter, &out.HeadFilter
+		*out = new(WildcardFilter)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SCMTraits.
+func (in *SCMTraits) DeepCopy() *SCMTraits {
+	if in == nil {
+		return nil
+	}
+	out := new(SCMTraits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHLauncher) DeepCopyInto(out *SSHLauncher) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int64)
+		**out = **in
+	}
+	if in.HostKeyVerificationStrategy != nil {
+		in, out := &in.HostKeyVerificationStrategy, &out.HostKeyVerificationStrategy
+		*out = new(SSHHostKeyVerificationStrategy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHLauncher.
+func (in *SSHLauncher) DeepCopy() *SSHLauncher {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHLauncher)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHPrivateKeyCredential) DeepCopyInto(out *SSHPrivateKeyCredential) {
+	*out = *in
+	out.PrivateKeySecretRef = in.PrivateKeySecretRef
+	if in.PassphraseSecretRef != nil {
+		in, out := &in.PassphraseSecretRef, &out.PassphraseSecretRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHPrivateKeyCredential.
+func (in *SSHPrivateKeyCredential) DeepCopy() *SSHPrivateKeyCredential {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHPrivateKeyCredential)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScanObservation) DeepCopyInto(out *ScanObservation) {
+	*out = *in
+	if in.Timestamp != nil {
+		in, out := &in.Timestamp, &out.Timestamp
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScanObservation.
+func (in *ScanObservation) DeepCopy() *ScanObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(ScanObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduledRetentionStrategy) DeepCopyInto(out *ScheduledRetentionStrategy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledRetentionStrategy.
+func (in *ScheduledRetentionStrategy) DeepCopy() *ScheduledRetentionStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduledRetentionStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretTextCredential) DeepCopyInto(out *SecretTextCredential) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretTextCredential.
+func (in *SecretTextCredential) DeepCopy() *SecretTextCredential {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretTextCredential)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ToolLocation) DeepCopyInto(out *ToolLocation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolLocation.
+func (in *ToolLocation) DeepCopy() *ToolLocation {
+	if in == nil {
+		return nil
+	}
+	out := new(ToolLocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpstreamTrigger) DeepCopyInto(out *UpstreamTrigger) {
+	*out = *in
+	if in.Projects != nil {
+		in, out := &in.Projects, &out.Projects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpstreamTrigger.
+func (in *UpstreamTrigger) DeepCopy() *UpstreamTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(UpstreamTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsernamePasswordCredential) DeepCopyInto(out *UsernamePasswordCredential) {
+	*out = *in
+	out.PasswordSecretRef = in.PasswordSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsernamePasswordCredential.
+func (in *UsernamePasswordCredential) DeepCopy() *UsernamePasswordCredential {
+	if in == nil {
+		return nil
+	}
+	out := new(UsernamePasswordCredential)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WildcardFilter) DeepCopyInto(out *WildcardFilter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WildcardFilter.
+func (in *WildcardFilter) DeepCopy() *WildcardFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(WildcardFilter)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -19,6 +19,24 @@ package v1alpha1
 
 import resource "github.com/crossplane/crossplane-runtime/pkg/resource"
 
+// GetItems of this CredentialList.
+func (l *CredentialList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
+
+// GetItems of this FolderList.
+func (l *FolderList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
+
 // GetItems of this JenkinsNodeList.
 func (l *JenkinsNodeList) GetItems() []resource.Managed {
 	items := make([]resource.Managed, len(l.Items))
@@ -36,3 +54,21 @@ func (l *JobList) GetItems() []resource.Managed {
 	}
 	return items
 }
+
+// GetItems of this MultibranchPipelineList.
+func (l *MultibranchPipelineList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
+
+// GetItems of this OrganizationFolderList.
+func (l *OrganizationFolderList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
@@ -27,30 +27,144 @@ import (
 
 // A ProviderConfigSpec defines the desired state of a ProviderConfig.
 type ProviderConfigSpec struct {
-	// Credentials required to authenticate to this provider.
+	// Credentials required to authenticate to this provider. The credentials
+	// are either a bare password or token, or a JSON document with the keys
+	// url, username and token that fully describes the connection. Values of
+	// the document take precedence over username and baseurl.
 	Credentials ProviderCredentials `json:"credentials"`
-	Username    string              `json:"username"`
-	BaseURL     string              `json:"baseurl"`
+
+	// Username used to authenticate to Jenkins.
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// BaseURL of the Jenkins instance.
+	// +optional
+	BaseURL string `json:"baseurl,omitempty"`
+
+	// Auth selects how the provider authenticates to Jenkins. Defaults to
+	// basic authentication using the username and the credentials as the
+	// password.
+	// +optional
+	Auth *ProviderAuth `json:"auth,omitempty"`
+
+	// TLS configures the TLS connection to Jenkins. The system trust store
+	// is used when it is omitted.
+	// +optional
+	TLS *TLSConfig `json:"tls,omitempty"`
+}
+
+// An AuthMethod is a method of authenticating to Jenkins.
+type AuthMethod string
+
+// Supported authentication methods.
+const (
+	// AuthMethodBasic authenticates using a username and password.
+	AuthMethodBasic AuthMethod = "Basic"
+
+	// AuthMethodAPIToken authenticates using a username and a Jenkins API
+	// token. Requests authenticated with an API token do not need a CSRF
+	// crumb.
+	AuthMethodAPIToken AuthMethod = "APIToken"
+
+	// AuthMethodBearer sends the token as a bearer token in the Authorization
+	// header, e.g. for Jenkins instances behind an OIDC reverse proxy.
+	AuthMethodBearer AuthMethod = "Bearer"
+)
+
+// ProviderAuth configures how the provider authenticates to Jenkins.
+type ProviderAuth struct {
+	// Method used to authenticate to Jenkins.
+	// +kubebuilder:validation:Enum=Basic;APIToken;Bearer
+	// +kubebuilder:default=Basic
+	Method AuthMethod `json:"method"`
+
+	// UsernameSecretRef references a secret key containing the username.
+	// Takes precedence over spec.username.
+	// +optional
+	UsernameSecretRef *xpv1.SecretKeySelector `json:"usernameSecretRef,omitempty"`
+
+	// TokenSecretRef references a secret key containing the password, API
+	// token or bearer token. Takes precedence over spec.credentials.
+	// +optional
+	TokenSecretRef *xpv1.SecretKeySelector `json:"tokenSecretRef,omitempty"`
 }
 
 // ProviderCredentials required to authenticate.
 type ProviderCredentials struct {
-	// Source of the provider credentials.
+	// Source of the provider credentials. The InjectedIdentity source uses the
+	// service account token of the provider pod as a bearer token, or the
+	// projected token at fs.path if set.
 	// +kubebuilder:validation:Enum=None;Secret;InjectedIdentity;Environment;Filesystem
 	Source xpv1.CredentialsSource `json:"source"`
 
 	xpv1.CommonCredentialSelectors `json:",inline"`
 }
 
+// TLSConfig configures the TLS connection to Jenkins.
+type TLSConfig struct {
+	// CABundle selects PEM encoded CA certificates used to verify the Jenkins
+	// server certificate, in addition to the system trust store.
+	// +optional
+	CABundle *DataKeySelector `json:"caBundle,omitempty"`
+
+	// ClientCert selects a PEM encoded client certificate presented to
+	// Jenkins. Requires ClientKey.
+	// +optional
+	ClientCert *DataKeySelector `json:"clientCert,omitempty"`
+
+	// ClientKey selects the PEM encoded private key of ClientCert.
+	// +optional
+	ClientKey *DataKeySelector `json:"clientKey,omitempty"`
+
+	// InsecureSkipVerify disables verification of the Jenkins server
+	// certificate. Only use this for test instances.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// A DataKeySelector selects a key of either a Secret or a ConfigMap.
+type DataKeySelector struct {
+	// SecretKeyRef selects a key of a Secret.
+	// +optional
+	SecretKeyRef *xpv1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+
+	// ConfigMapKeyRef selects a key of a ConfigMap.
+	// +optional
+	ConfigMapKeyRef *ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+}
+
+// A ConfigMapKeySelector selects a key of a ConfigMap.
+type ConfigMapKeySelector struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+
+	// The key to select.
+	Key string `json:"key"`
+}
+
 // A ProviderConfigStatus reflects the observed state of a ProviderConfig.
 type ProviderConfigStatus struct {
 	xpv1.ProviderConfigStatus `json:",inline"`
+
+	// JenkinsVersion is the version reported by the Jenkins instance.
+	// +optional
+	JenkinsVersion string `json:"jenkinsVersion,omitempty"`
+
+	// AuthenticatedUser is the Jenkins user the provider authenticates as.
+	// +optional
+	AuthenticatedUser string `json:"authenticatedUser,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 
 // A ProviderConfig configures a Jenkins provider.
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="VERSION",type="string",JSONPath=".status.jenkinsVersion"
+// +kubebuilder:printcolumn:name="USER",type="string",JSONPath=".status.authenticatedUser"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="SECRET-NAME",type="string",JSONPath=".spec.credentials.secretRef.name",priority=1
 // +kubebuilder:resource:scope=Cluster
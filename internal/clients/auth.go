@@ -0,0 +1,49 @@
+package clients
+
+import (
+	"net/http"
+
+	"github.com/crossplane/provider-jenkins/apis/v1alpha1"
+)
+
+// newAuthTransport returns a transport that authenticates every request,
+// including redirects, using the method selected by the supplied Config.
+func newAuthTransport(c Config, base http.RoundTripper) http.RoundTripper {
+	switch c.AuthMethod {
+	case v1alpha1.AuthMethodAPIToken:
+		return &basicAuthTransport{username: c.Username, password: c.Token, base: base}
+	case v1alpha1.AuthMethodBearer:
+		return &bearerAuthTransport{token: c.Token, base: base}
+	default:
+		if c.Username == "" && c.Password == "" {
+			return base
+		}
+		return &basicAuthTransport{username: c.Username, password: c.Password, base: base}
+	}
+}
+
+// basicAuthTransport authenticates requests using HTTP basic authentication.
+type basicAuthTransport struct {
+	username string
+	password string
+	base     http.RoundTripper
+}
+
+func (t *basicAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the request it was given.
+	r := req.Clone(req.Context())
+	r.SetBasicAuth(t.username, t.password)
+	return t.base.RoundTrip(r)
+}
+
+// bearerAuthTransport authenticates requests using a bearer token.
+type bearerAuthTransport struct {
+	token string
+	base  http.RoundTripper
+}
+
+func (t *bearerAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	r.Header.Set("Authorization", "Bearer "+t.token)
+	return t.base.RoundTrip(r)
+}
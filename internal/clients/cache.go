@@ -0,0 +1,130 @@
+package clients
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-jenkins/apis/v1alpha1"
+)
+
+// A ClientCache reuses Jenkins clients across reconciles. Clients are cached
+// per ProviderConfig UID and replaced when the ProviderConfig's generation or
+// the credentials and TLS material it references change. The generation,
+// unlike the resourceVersion, is not bumped by status updates such as health
+// checks and usage counts. Comparing the resolved Config rather than Secret
+// resourceVersions also picks up changes to Environment and Filesystem
+// credential sources.
+type ClientCache struct {
+	newServiceFn func(c Config) (Client, error)
+
+	mu      sync.Mutex
+	clients map[types.UID]cachedClient
+}
+
+type cachedClient struct {
+	version string
+	client  Client
+}
+
+// NewClientCache returns a ClientCache that creates clients using the
+// supplied function.
+func NewClientCache(fn func(c Config) (Client, error)) *ClientCache {
+	return &ClientCache{newServiceFn: fn, clients: map[types.UID]cachedClient{}}
+}
+
+// Get returns a client for the ProviderConfig referenced by the supplied
+// managed resource. It does not track ProviderConfig usage; callers are
+// expected to have done so before calling Get.
+func (cc *ClientCache) Get(ctx context.Context, c client.Client, mg resource.Managed) (Client, error) {
+	ref := mg.GetProviderConfigReference()
+	if ref == nil {
+		return nil, errors.New("providerConfigRef is not given")
+	}
+	pc := &v1alpha1.ProviderConfig{}
+	if err := c.Get(ctx, types.NamespacedName{Name: ref.Name}, pc); err != nil {
+		return nil, errors.Wrap(err, "cannot get referenced ProviderConfig")
+	}
+	cfg, err := ResolveConfig(ctx, c, pc)
+	if err != nil {
+		return nil, err
+	}
+	version, err := configVersion(pc, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cached, ok := cc.clients[pc.GetUID()]
+	if ok && cached.version == version {
+		return cached.client, nil
+	}
+	svc, err := cc.newServiceFn(*cfg)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		closeIdleConnections(cached.client)
+	}
+	cc.clients[pc.GetUID()] = cachedClient{version: version, client: svc}
+	return svc, nil
+}
+
+// EvictOnDelete removes the client of each ProviderConfig from the cache when
+// the ProviderConfig is deleted.
+func (cc *ClientCache) EvictOnDelete(ctx context.Context, informers cache.Informers) error {
+	i, err := informers.GetInformer(ctx, &v1alpha1.ProviderConfig{})
+	if err != nil {
+		return errors.Wrap(err, "cannot get ProviderConfig informer")
+	}
+	i.AddEventHandler(toolscache.ResourceEventHandlerFuncs{DeleteFunc: func(obj interface{}) {
+		if d, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+			obj = d.Obj
+		}
+		if pc, ok := obj.(*v1alpha1.ProviderConfig); ok {
+			cc.evict(pc.GetUID())
+		}
+	}})
+	return nil
+}
+
+// evict removes the client of the ProviderConfig with the supplied UID.
+func (cc *ClientCache) evict(uid types.UID) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if cached, ok := cc.clients[uid]; ok {
+		closeIdleConnections(cached.client)
+		delete(cc.clients, uid)
+	}
+}
+
+// configVersion identifies the supplied ProviderConfig generation and
+// resolved Config without retaining the credentials themselves.
+func configVersion(pc *v1alpha1.ProviderConfig, cfg *Config) (string, error) {
+	b, err := json.Marshal(cfg)
+	if err != nil {
+		return "", errors.Wrap(err, "cannot hash Jenkins client config")
+	}
+	sum := sha256.Sum256(b)
+	return strconv.FormatInt(pc.GetGeneration(), 10) + "/" + hex.EncodeToString(sum[:]), nil
+}
+
+// closeIdleConnections releases the connections held by a client that is
+// being replaced.
+func closeIdleConnections(svc Client) {
+	if c, ok := svc.(*jenkinsClient); ok {
+		c.http.CloseIdleConnections()
+	}
+}
@@ -0,0 +1,127 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	dashboardv1alpha1 "github.com/crossplane/provider-jenkins/apis/dashboard/v1alpha1"
+	"github.com/crossplane/provider-jenkins/apis/v1alpha1"
+)
+
+const credentialsEnv = "JENKINS_TEST_CREDENTIALS"
+
+func TestClientCacheGet(t *testing.T) {
+	type change struct {
+		generation  int64
+		status      bool
+		credentials string
+	}
+
+	cases := map[string]struct {
+		reason string
+		change change
+		want   int
+	}{
+		"Unchanged": {
+			reason: "A client should be reused if nothing changed.",
+			want:   1,
+		},
+		"StatusUpdated": {
+			reason: "A client should be reused if only the status of the ProviderConfig changed.",
+			change: change{status: true},
+			want:   1,
+		},
+		"SpecUpdated": {
+			reason: "A client should be replaced if the generation of the ProviderConfig changed.",
+			change: change{generation: 2},
+			want:   2,
+		},
+		"CredentialsUpdated": {
+			reason: "A client should be replaced if the resolved credentials changed.",
+			change: change{credentials: "rotated"},
+			want:   2,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			t.Setenv(credentialsEnv, "secret")
+			pc := &v1alpha1.ProviderConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "jenkins", UID: "uid", Generation: 1},
+				Spec: v1alpha1.ProviderConfigSpec{
+					BaseURL: "https://jenkins.example.org",
+					Credentials: v1alpha1.ProviderCredentials{
+						Source:                    xpv1.CredentialsSourceEnvironment,
+						CommonCredentialSelectors: xpv1.CommonCredentialSelectors{Env: &xpv1.EnvSelector{Name: credentialsEnv}},
+					},
+				},
+			}
+			kube := newKube(t, pc)
+			mg := &dashboardv1alpha1.Job{}
+			mg.SetProviderConfigReference(&xpv1.Reference{Name: pc.GetName()})
+
+			created := 0
+			cc := NewClientCache(func(c Config) (Client, error) {
+				created++
+				return &jenkinsClient{http: &http.Client{}}, nil
+			})
+			if _, err := cc.Get(ctx, kube, mg); err != nil {
+				t.Fatalf("Get(...): %v", err)
+			}
+
+			if tc.change.status {
+				pc.Status.JenkinsVersion = "2.375.1"
+				if err := kube.Status().Update(ctx, pc); err != nil {
+					t.Fatalf("Status().Update(...): %v", err)
+				}
+			}
+			if tc.change.generation != 0 {
+				pc.SetGeneration(tc.change.generation)
+				if err := kube.Update(ctx, pc); err != nil {
+					t.Fatalf("Update(...): %v", err)
+				}
+			}
+			if tc.change.credentials != "" {
+				t.Setenv(credentialsEnv, tc.change.credentials)
+			}
+			if _, err := cc.Get(ctx, kube, mg); err != nil {
+				t.Fatalf("Get(...): %v", err)
+			}
+
+			if diff := cmp.Diff(tc.want, created); diff != "" {
+				t.Errorf("\n%s\nGet(...): -want clients created, +got clients created:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestClientCacheEvict(t *testing.T) {
+	cc := NewClientCache(func(c Config) (Client, error) { return &jenkinsClient{http: &http.Client{}}, nil })
+	cc.clients["uid"] = cachedClient{version: "1", client: &jenkinsClient{http: &http.Client{}}}
+
+	cc.evict("uid")
+	cc.evict("unknown")
+
+	if diff := cmp.Diff(0, len(cc.clients)); diff != "" {
+		t.Errorf("evict(...): -want cached clients, +got cached clients:\n%s", diff)
+	}
+}
+
+func newKube(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	s := runtime.NewScheme()
+	if err := v1alpha1.SchemeBuilder.AddToScheme(s); err != nil {
+		t.Fatalf("AddToScheme(...): %v", err)
+	}
+	return fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).Build()
+}
@@ -0,0 +1,80 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// globalDomain is the name of the global credential domain in API paths.
+const globalDomain = "_"
+
+// A CredentialDomain identifies a credential domain of the system credential
+// store, or of the credential store of a folder.
+type CredentialDomain struct {
+	// Folder is the full name of the folder whose store contains the domain.
+	// The system store is used if it is empty.
+	Folder string
+	// Domain is the name of the domain. The global domain is used if it is
+	// empty.
+	Domain string
+}
+
+// base returns the API path of the credential domain.
+func (d CredentialDomain) base() string {
+	domain := d.Domain
+	if domain == "" {
+		domain = globalDomain
+	}
+	if d.Folder == "" {
+		return "/credentials/store/system/domain/" + url.PathEscape(domain)
+	}
+	return itemBase(d.Folder) + "/credentials/store/folder/domain/" + url.PathEscape(domain)
+}
+
+// credentialBase returns the API path of the credential with the supplied ID.
+func credentialBase(d CredentialDomain, id string) string {
+	return d.base() + "/credential/" + url.PathEscape(id)
+}
+
+// A Credential describes a credential as reported by the Jenkins API. It does
+// not include secret material.
+type Credential struct {
+	ID          string `json:"id"`
+	FullName    string `json:"fullName"`
+	DisplayName string `json:"displayName"`
+	TypeName    string `json:"typeName"`
+	Description string `json:"description"`
+}
+
+// GetCredential returns the credential with the supplied ID.
+func (c *jenkinsClient) GetCredential(ctx context.Context, d CredentialDomain, id string) (*Credential, error) {
+	cred := &Credential{}
+	if err := c.getJSON(ctx, credentialBase(d, id), nil, cred); err != nil {
+		return nil, err
+	}
+	return cred, nil
+}
+
+// GetCredentialConfig returns the config.xml of the credential with the
+// supplied ID. Jenkins encrypts the secret material it contains.
+func (c *jenkinsClient) GetCredentialConfig(ctx context.Context, d CredentialDomain, id string) (string, error) {
+	b, _, err := c.do(ctx, http.MethodGet, credentialBase(d, id)+"/config.xml", nil, nil, "")
+	return string(b), err
+}
+
+// CreateCredential creates a credential from a config.xml.
+func (c *jenkinsClient) CreateCredential(ctx context.Context, d CredentialDomain, config string) error {
+	return c.postXML(ctx, d.base()+"/createCredentials", nil, config)
+}
+
+// UpdateCredentialConfig replaces the config.xml of the credential with the
+// supplied ID.
+func (c *jenkinsClient) UpdateCredentialConfig(ctx context.Context, d CredentialDomain, id string, config string) error {
+	return c.postXML(ctx, credentialBase(d, id)+"/config.xml", nil, config)
+}
+
+// DeleteCredential deletes the credential with the supplied ID.
+func (c *jenkinsClient) DeleteCredential(ctx context.Context, d CredentialDomain, id string) error {
+	return c.post(ctx, credentialBase(d, id)+"/doDelete", nil)
+}
@@ -0,0 +1,62 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-jenkins/apis/v1alpha1"
+)
+
+// serviceAccountTokenPath is where Kubernetes mounts the service account token
+// of the provider pod.
+const serviceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token" //nolint:gosec // Not a credential.
+
+// credentials is the structured document a credentials source may contain
+// instead of a bare password or token, e.g.
+//
+//	{"url": "https://jenkins.example.org", "username": "admin", "token": "..."}
+//
+// Values set in the document take precedence over spec.baseurl and
+// spec.username of the ProviderConfig.
+type credentials struct {
+	URL      string `json:"url,omitempty"`
+	Username string `json:"username,omitempty"`
+	Token    string `json:"token,omitempty"`
+}
+
+// parseCredentials parses the supplied credentials. Credentials that are not a
+// JSON object are used as the password or token.
+func parseCredentials(data []byte) (credentials, error) {
+	data = bytes.TrimSpace(data)
+	if !bytes.HasPrefix(data, []byte("{")) {
+		return credentials{Token: string(data)}, nil
+	}
+	c := credentials{}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return credentials{}, errors.Wrap(err, "cannot parse credentials document")
+	}
+	return c, nil
+}
+
+// extractCredentials returns the credentials of the supplied source. The
+// InjectedIdentity source reads the service account token of the provider
+// pod, or the projected token at the filesystem path if one is selected.
+func extractCredentials(ctx context.Context, c client.Client, pcc v1alpha1.ProviderCredentials) ([]byte, error) {
+	if pcc.Source != xpv1.CredentialsSourceInjectedIdentity {
+		return resource.CommonCredentialExtractor(ctx, pcc.Source, c, pcc.CommonCredentialSelectors)
+	}
+	path := serviceAccountTokenPath
+	if pcc.Fs != nil {
+		path = pcc.Fs.Path
+	}
+	b, err := os.ReadFile(path) //nolint:gosec // The path is configured by the ProviderConfig.
+	return b, errors.Wrap(err, "cannot read injected identity token")
+}
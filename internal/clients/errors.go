@@ -0,0 +1,127 @@
+package clients
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// A Reason classifies an error returned by the Jenkins API.
+type Reason string
+
+// Error reasons.
+const (
+	ReasonUnknown       Reason = "Unknown"
+	ReasonNotFound      Reason = "NotFound"
+	ReasonUnauthorized  Reason = "Unauthorized"
+	ReasonForbidden     Reason = "Forbidden"
+	ReasonConflict      Reason = "Conflict"
+	ReasonCrumbRejected Reason = "CrumbRejected"
+)
+
+// An APIError is returned when Jenkins responds with an error status. The
+// StatusCode is zero if only the X-Error message of the response is known.
+type APIError struct {
+	Method     string
+	Endpoint   string
+	StatusCode int
+	Reason     Reason
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s %s", e.Method, e.Endpoint)
+	if e.StatusCode != 0 {
+		msg += fmt.Sprintf(": %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	switch e.Reason { //nolint:exhaustive
+	case ReasonUnauthorized:
+		msg += ": Jenkins rejected the ProviderConfig credentials"
+	case ReasonForbidden:
+		msg += ": the ProviderConfig user lacks the required Jenkins permission"
+	case ReasonCrumbRejected:
+		msg += ": Jenkins rejected the CSRF crumb"
+	}
+	return msg
+}
+
+func newAPIError(method string, endpoint string, rsp *http.Response, body []byte) *APIError {
+	e := &APIError{
+		Method:     method,
+		Endpoint:   endpoint,
+		StatusCode: rsp.StatusCode,
+		Reason:     ReasonUnknown,
+		Message:    rsp.Header.Get("X-Error"),
+	}
+
+	switch {
+	case rsp.StatusCode == http.StatusNotFound:
+		e.Reason = ReasonNotFound
+	case rsp.StatusCode == http.StatusUnauthorized:
+		e.Reason = ReasonUnauthorized
+	case rsp.StatusCode == http.StatusForbidden && strings.Contains(string(body), "No valid crumb"):
+		e.Reason = ReasonCrumbRejected
+	case rsp.StatusCode == http.StatusForbidden:
+		e.Reason = ReasonForbidden
+	case rsp.StatusCode == http.StatusConflict:
+		e.Reason = ReasonConflict
+	// Jenkins reports an existing item as a bad request.
+	case rsp.StatusCode == http.StatusBadRequest && strings.Contains(e.Message, "already exists"):
+		e.Reason = ReasonConflict
+	}
+	return e
+}
+
+// newMessageError returns an APIError for the supplied X-Error message of a
+// response whose status is unknown.
+func newMessageError(method string, endpoint string, message string) *APIError {
+	e := &APIError{Method: method, Endpoint: endpoint, Reason: ReasonUnknown, Message: message}
+	if strings.Contains(message, "already exists") {
+		e.Reason = ReasonConflict
+	}
+	return e
+}
+
+// ReasonFor returns the reason of an error returned by the Jenkins API, or
+// ReasonUnknown if the error was not returned by the Jenkins API.
+func ReasonFor(err error) Reason {
+	var e *APIError
+	if errors.As(err, &e) {
+		return e.Reason
+	}
+	return ReasonUnknown
+}
+
+// IsNotFound returns true if the error indicates the requested Jenkins item
+// does not exist.
+func IsNotFound(err error) bool {
+	return ReasonFor(err) == ReasonNotFound
+}
+
+// IsUnauthorized returns true if the error indicates Jenkins rejected the
+// supplied credentials.
+func IsUnauthorized(err error) bool {
+	return ReasonFor(err) == ReasonUnauthorized
+}
+
+// IsForbidden returns true if the error indicates the authenticated user lacks
+// a required permission.
+func IsForbidden(err error) bool {
+	return ReasonFor(err) == ReasonForbidden
+}
+
+// IsConflict returns true if the error indicates the Jenkins item already
+// exists or was concurrently modified.
+func IsConflict(err error) bool {
+	return ReasonFor(err) == ReasonConflict
+}
+
+// IsCrumbRejected returns true if the error indicates Jenkins rejected the
+// CSRF crumb sent with a request.
+func IsCrumbRejected(err error) bool {
+	return ReasonFor(err) == ReasonCrumbRejected
+}
@@ -0,0 +1,617 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake provides an in-process fake Jenkins server that emulates the
+// parts of the Jenkins REST API used by the provider.
+package fake
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/crossplane/provider-jenkins/internal/clients"
+)
+
+// Credentials and metadata reported by the fake server.
+const (
+	Username = "admin"
+	Password = "password"
+	Token    = "api-token"
+	Version  = "2.375.1"
+
+	crumbField       = "Jenkins-Crumb"
+	crumb            = "fake-crumb"
+	folderClass      = "com.cloudbees.hudson.plugins.folder.Folder"
+	multibranchClass = "org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject"
+	orgFolderClass   = "jenkins.branch.OrganizationFolder"
+	jobClass         = "hudson.model.FreeStyleProject"
+
+	inboundLauncherClass = "hudson.slaves.JNLPLauncher"
+)
+
+// An Item is a job or folder stored by the fake server.
+type Item struct {
+	Folder bool
+	Config string
+
+	// Computed folders, i.e. multibranch projects and organization folders,
+	// can be scanned. Scans is the number of scans scheduled through the API
+	// and LastScan the time of the last one.
+	Computed bool
+	Scans    int
+	LastScan time.Time
+}
+
+// A Node is an agent stored by the fake server.
+type Node struct {
+	NumExecutors int64
+	Description  string
+	RemoteFS     string
+	Label        string
+	Offline      bool
+
+	// TemporarilyOffline and OfflineCause are reported by the computer API.
+	TemporarilyOffline bool
+	OfflineCause       string
+
+	// BusyExecutors is the number of executors running a build.
+	BusyExecutors int64
+
+	// DenyConnect forbids fetching the agent secret, as if the user lacked
+	// the Computer/Connect permission.
+	DenyConnect bool
+
+	// Launcher is the class of the node's launcher. The inbound agent
+	// launcher is used if it is empty.
+	Launcher string
+
+	// Config is the config.xml of the node. It is generated from the above
+	// fields if it is empty.
+	Config string
+}
+
+// nodeConfig is the part of a node's config.xml understood by the fake
+// server.
+type nodeConfig struct {
+	XMLName      xml.Name `xml:"slave"`
+	Name         string   `xml:"name"`
+	Description  string   `xml:"description"`
+	RemoteFS     string   `xml:"remoteFS"`
+	NumExecutors int64    `xml:"numExecutors"`
+	Label        string   `xml:"label"`
+	Launcher     struct {
+		Class string `xml:"class,attr"`
+	} `xml:"launcher"`
+}
+
+// A Server is a fake Jenkins server backed by in-memory state.
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	items       map[string]*Item
+	nodes       map[string]*Node
+	credentials map[string]string
+}
+
+var (
+	credentialID = regexp.MustCompile(`<id>([^<]*)</id>`)
+	// credentialSecret matches the elements of a credential that Jenkins
+	// encrypts when returning its config.xml.
+	credentialSecret = regexp.MustCompile(`<(password|secret|passphrase|privateKey|secretBytes|uploadedKeystoreBytes)>[^<]*</`)
+)
+
+// NewServer starts and returns a new fake Jenkins server. Callers should call
+// Close when finished.
+func NewServer() *Server {
+	s := &Server{items: map[string]*Item{}, nodes: map[string]*Node{}, credentials: map[string]string{}}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// NewTLSServer starts and returns a new fake Jenkins server serving HTTPS with
+// a self-signed certificate. Callers should call Close when finished.
+func NewTLSServer() *Server {
+	s := &Server{items: map[string]*Item{}, nodes: map[string]*Node{}, credentials: map[string]string{}}
+	s.Server = httptest.NewTLSServer(s)
+	return s
+}
+
+// Config returns a client configuration that can be used to connect to the
+// fake server.
+func (s *Server) Config() clients.Config {
+	cfg := clients.Config{BaseURL: s.URL, Username: Username, Password: Password}
+	if c := s.Certificate(); c != nil {
+		cfg.CABundle = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.Raw})
+	}
+	return cfg
+}
+
+// AddFolder adds a folder with the supplied full name and no config.xml.
+func (s *Server) AddFolder(fullName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items[fullName] = &Item{Folder: true}
+}
+
+// AddJob adds a job with the supplied full name and config.xml.
+func (s *Server) AddJob(fullName string, config string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items[fullName] = &Item{Config: config}
+}
+
+// GetItem returns the job or folder with the supplied full name.
+func (s *Server) GetItem(fullName string) (Item, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, ok := s.items[fullName]
+	if !ok {
+		return Item{}, false
+	}
+	return *i, true
+}
+
+// AddNode adds a node with the supplied name.
+func (s *Server) AddNode(name string, n Node) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nodes[name] = &n
+}
+
+// GetNode returns the node with the supplied name.
+func (s *Server) GetNode(name string) (Node, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n, ok := s.nodes[name]
+	if !ok {
+		return Node{}, false
+	}
+	return *n, true
+}
+
+// GetCredential returns the config.xml, including secret material, of the
+// credential with the supplied ID in the supplied domain. The system store is
+// used if folder is empty, and the global domain if domain is empty.
+func (s *Server) GetCredential(folder, domain, id string) (string, bool) {
+	if domain == "" {
+		domain = "_"
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.credentials[folder+"|"+domain+"|"+id]
+	return c, ok
+}
+
+// AgentSecret returns the inbound agent secret of the node with the supplied
+// name.
+func AgentSecret(name string) string {
+	return "agent-secret-" + name
+}
+
+// ServeHTTP routes a request to the emulated Jenkins endpoints.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u, p, basic := r.BasicAuth()
+	token := basic && u == Username && p == Token
+	bearer := r.Header.Get("Authorization") == "Bearer "+Token
+	if !token && !bearer && (!basic || u != Username || p != Password) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	// Like Jenkins, requests authenticated with an API token need no crumb.
+	if r.Method == http.MethodPost && !token && r.Header.Get(crumbField) != crumb {
+		http.Error(w, "No valid crumb was included in the request", http.StatusForbidden)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	segs := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch segs[0] {
+	case "crumbIssuer":
+		writeJSON(w, map[string]string{"crumbRequestField": crumbField, "crumb": crumb})
+	case "whoAmI":
+		writeJSON(w, map[string]interface{}{"name": Username, "authenticated": true, "anonymous": false})
+	case "computer":
+		s.serveComputer(w, r, segs[1:])
+	case "credentials":
+		s.serveCredentials(w, r, "", segs[1:])
+	default:
+		s.serveItem(w, r, segs)
+	}
+}
+
+func (s *Server) serveItem(w http.ResponseWriter, r *http.Request, segs []string) {
+	names := []string{}
+	for len(segs) >= 2 && segs[0] == "job" {
+		names = append(names, segs[1])
+		segs = segs[2:]
+	}
+	fullName := strings.Join(names, "/")
+	action := strings.Join(segs, "/")
+
+	item, ok := s.items[fullName]
+	if fullName != "" && !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if len(segs) > 0 && segs[0] == "credentials" && item != nil && item.Folder {
+		s.serveCredentials(w, r, fullName, segs[1:])
+		return
+	}
+
+	switch {
+	case action == "api/json" && r.Method == http.MethodGet:
+		if fullName == "" {
+			w.Header().Set("X-Jenkins", Version)
+			writeJSON(w, map[string]interface{}{"_class": "hudson.model.Hudson", "mode": "NORMAL", "useCrumbs": true, "useSecurity": true})
+			return
+		}
+		s.writeItem(w, fullName, item)
+
+	case action == "createItem" && r.Method == http.MethodPost:
+		if item != nil && !item.Folder {
+			http.NotFound(w, r)
+			return
+		}
+		name := r.URL.Query().Get("name")
+		child := strings.TrimPrefix(fullName+"/"+name, "/")
+		if _, exists := s.items[child]; exists {
+			w.Header().Set("X-Error", "A job already exists with the name ‘"+name+"’")
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.URL.Query().Get("mode") == folderClass {
+			s.items[child] = &Item{Folder: true}
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		computed := strings.Contains(string(body), "<"+multibranchClass) || strings.Contains(string(body), "<"+orgFolderClass)
+		s.items[child] = &Item{Folder: computed || strings.Contains(string(body), "<"+folderClass), Computed: computed, Config: string(body)}
+
+	case action == "config.xml" && item != nil && r.Method == http.MethodGet:
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = io.WriteString(w, item.Config)
+
+	case action == "config.xml" && item != nil && r.Method == http.MethodPost:
+		body, _ := io.ReadAll(r.Body)
+		item.Config = string(body)
+
+	case action == "doDelete" && item != nil && r.Method == http.MethodPost:
+		for n := range s.items {
+			if n == fullName || strings.HasPrefix(n, fullName+"/") {
+				delete(s.items, n)
+			}
+		}
+
+	case (action == "indexing/api/json" || action == "computation/api/json") && item != nil && item.Computed && item.Scans > 0 && r.Method == http.MethodGet:
+		writeJSON(w, map[string]interface{}{"result": "SUCCESS", "timestamp": item.LastScan.UnixMilli()})
+
+	case action == "build" && item != nil && item.Computed && r.Method == http.MethodPost:
+		item.Scans++
+		item.LastScan = time.Now()
+
+	case action == "confirmRename" && item != nil && r.Method == http.MethodPost:
+		parent, _ := splitFullName(fullName)
+		s.moveItem(w, fullName, strings.TrimPrefix(parent+"/"+r.URL.Query().Get("newName"), "/"))
+
+	case action == "move/move" && item != nil && r.Method == http.MethodPost:
+		parent := strings.Trim(r.URL.Query().Get("destination"), "/")
+		if p, ok := s.items[parent]; parent != "" && (!ok || !p.Folder) {
+			http.Error(w, "No such folder "+parent, http.StatusBadRequest)
+			return
+		}
+		_, name := splitFullName(fullName)
+		s.moveItem(w, fullName, strings.TrimPrefix(parent+"/"+name, "/"))
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// moveItem moves the item with the supplied full name, along with any items it
+// contains, to the supplied full name.
+func (s *Server) moveItem(w http.ResponseWriter, from, to string) {
+	if _, exists := s.items[to]; exists {
+		http.Error(w, "An item named "+to+" already exists", http.StatusBadRequest)
+		return
+	}
+	moved := map[string]*Item{}
+	for n, i := range s.items {
+		if n == from || strings.HasPrefix(n, from+"/") {
+			delete(s.items, n)
+			moved[to+strings.TrimPrefix(n, from)] = i
+		}
+	}
+	for n, i := range moved {
+		s.items[n] = i
+	}
+}
+
+func (s *Server) writeItem(w http.ResponseWriter, fullName string, item *Item) {
+	name := fullName[strings.LastIndex(fullName, "/")+1:]
+	rsp := map[string]interface{}{
+		"name":        name,
+		"displayName": name,
+		"fullName":    fullName,
+		"url":         s.URL + "/job/" + strings.ReplaceAll(fullName, "/", "/job/") + "/",
+	}
+	if !item.Folder {
+		rsp["_class"] = jobClass
+		rsp["buildable"] = true
+		rsp["color"] = "notbuilt"
+		writeJSON(w, rsp)
+		return
+	}
+	jobs := []map[string]string{}
+	for n := range s.items {
+		if strings.HasPrefix(n, fullName+"/") && !strings.Contains(strings.TrimPrefix(n, fullName+"/"), "/") {
+			jobs = append(jobs, map[string]string{"name": strings.TrimPrefix(n, fullName+"/")})
+		}
+	}
+	rsp["_class"] = folderClass
+	rsp["jobs"] = jobs
+	writeJSON(w, rsp)
+}
+
+func (s *Server) serveComputer(w http.ResponseWriter, r *http.Request, segs []string) {
+	if len(segs) == 1 && segs[0] == "doCreateItem" && r.Method == http.MethodPost {
+		s.createNode(w, r)
+		return
+	}
+	if len(segs) < 2 {
+		http.NotFound(w, r)
+		return
+	}
+	name, action := segs[0], strings.Join(segs[1:], "/")
+	n, ok := s.nodes[name]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch {
+	case action == "api/json" && r.Method == http.MethodGet:
+		writeJSON(w, n.computer(name, expandExecutors(r.URL.Query())))
+	case action == "slave-agent.jnlp" && r.Method == http.MethodGet && n.inbound() && n.DenyConnect:
+		http.Error(w, "admin is missing the Agent/Connect permission", http.StatusForbidden)
+	case action == "slave-agent.jnlp" && r.Method == http.MethodGet && n.inbound():
+		w.Header().Set("Content-Type", "application/x-java-jnlp-file")
+		_, _ = io.WriteString(w, `<jnlp codebase="`+s.URL+`/computer/`+name+`/" spec="1.0+">`+
+			`<application-desc main-class="hudson.remoting.jnlp.Main">`+
+			`<argument>`+AgentSecret(name)+`</argument><argument>`+name+`</argument>`+
+			`<argument>-workDir</argument><argument>`+n.RemoteFS+`</argument>`+
+			`<argument>-url</argument><argument>`+s.URL+`/</argument>`+
+			`</application-desc></jnlp>`)
+	case action == "config.xml" && r.Method == http.MethodGet:
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = io.WriteString(w, n.config(name))
+	case action == "config.xml" && r.Method == http.MethodPost:
+		body, _ := io.ReadAll(r.Body)
+		cfg := nodeConfig{}
+		if err := xml.Unmarshal(body, &cfg); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		n.NumExecutors, n.Description, n.RemoteFS, n.Label = cfg.NumExecutors, cfg.Description, cfg.RemoteFS, cfg.Label
+		n.Launcher = cfg.Launcher.Class
+		n.Config = string(body)
+	case action == "toggleOffline" && r.Method == http.MethodPost:
+		n.TemporarilyOffline = !n.TemporarilyOffline
+		n.OfflineCause = ""
+		if n.TemporarilyOffline {
+			n.OfflineCause = r.URL.Query().Get("offlineMessage")
+		}
+	case action == "changeOfflineCause" && r.Method == http.MethodPost && n.TemporarilyOffline:
+		n.OfflineCause = r.URL.Query().Get("offlineMessage")
+	case action == "doDelete" && r.Method == http.MethodPost:
+		delete(s.nodes, name)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// expandExecutors reports whether the supplied API query asks for what the
+// executors of a computer are running. Like Jenkins, executors are empty
+// objects at depth 0 unless a tree selects their current executable.
+func expandExecutors(q url.Values) bool {
+	if tree := q.Get("tree"); tree != "" {
+		return strings.Contains(tree, "executors[currentExecutable")
+	}
+	depth, _ := strconv.Atoi(q.Get("depth"))
+	return depth > 0
+}
+
+// computer returns the computer API representation of the node. The current
+// executable of each executor is only included if executors are expanded.
+func (n *Node) computer(name string, expand bool) map[string]interface{} {
+	executors := []map[string]interface{}{}
+	for i := int64(0); i < n.NumExecutors; i++ {
+		e := map[string]interface{}{}
+		if !expand {
+			executors = append(executors, e)
+			continue
+		}
+		e["currentExecutable"] = nil
+		if i < n.BusyExecutors {
+			e["currentExecutable"] = map[string]interface{}{"number": i + 1, "url": "/job/busy/" + strconv.FormatInt(i+1, 10) + "/"}
+		}
+		executors = append(executors, e)
+	}
+	monitors := map[string]interface{}{}
+	if !n.Offline {
+		monitors = map[string]interface{}{
+			"hudson.node_monitors.ArchitectureMonitor": "Linux (amd64)",
+			"hudson.node_monitors.DiskSpaceMonitor":    map[string]interface{}{"path": n.RemoteFS, "size": 10737418240},
+			"hudson.node_monitors.ResponseTimeMonitor": map[string]interface{}{"average": 42},
+		}
+	}
+	return map[string]interface{}{
+		"_class":             "hudson.slaves.SlaveComputer",
+		"displayName":        name,
+		"numExecutors":       n.NumExecutors,
+		"executors":          executors,
+		"offline":            n.Offline || n.TemporarilyOffline,
+		"temporarilyOffline": n.TemporarilyOffline,
+		"offlineCauseReason": n.OfflineCause,
+		"idle":               n.BusyExecutors == 0,
+		"jnlpAgent":          n.inbound(),
+		"monitorData":        monitors,
+	}
+}
+
+// inbound reports whether the node uses the inbound agent launcher.
+func (n *Node) inbound() bool {
+	return n.Launcher == "" || n.Launcher == inboundLauncherClass
+}
+
+// config returns the config.xml of the node.
+func (n *Node) config(name string) string {
+	if n.Config != "" {
+		return n.Config
+	}
+	cfg := nodeConfig{
+		Name:         name,
+		Description:  n.Description,
+		RemoteFS:     n.RemoteFS,
+		NumExecutors: n.NumExecutors,
+		Label:        n.Label,
+	}
+	cfg.Launcher.Class = n.Launcher
+	if cfg.Launcher.Class == "" {
+		cfg.Launcher.Class = inboundLauncherClass
+	}
+	b, _ := xml.MarshalIndent(cfg, "", "  ")
+	return "<?xml version='1.1' encoding='UTF-8'?>\n" + string(b)
+}
+
+func (s *Server) createNode(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	if _, exists := s.nodes[name]; exists {
+		http.Error(w, "Agent called ‘"+name+"’ already exists", http.StatusBadRequest)
+		return
+	}
+	form := struct {
+		NodeDescription string `json:"nodeDescription"`
+		RemoteFS        string `json:"remoteFS"`
+		NumExecutors    int64  `json:"numExecutors"`
+		LabelString     string `json:"labelString"`
+	}{}
+	if err := json.Unmarshal([]byte(r.URL.Query().Get("json")), &form); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.nodes[name] = &Node{
+		NumExecutors: form.NumExecutors,
+		Description:  form.NodeDescription,
+		RemoteFS:     form.RemoteFS,
+		Label:        form.LabelString,
+		Offline:      true,
+	}
+}
+
+// splitFullName splits a full item name into the full name of its parent
+// folder and the item name.
+func splitFullName(fullName string) (string, string) {
+	i := strings.LastIndex(fullName, "/")
+	if i < 0 {
+		return "", fullName
+	}
+	return fullName[:i], fullName[i+1:]
+}
+
+// serveCredentials serves the credential store of the supplied folder, or the
+// system store if folder is empty. The supplied path segments start at the
+// store, e.g. store/system/domain/_/createCredentials.
+func (s *Server) serveCredentials(w http.ResponseWriter, r *http.Request, folder string, segs []string) {
+	if len(segs) < 5 || segs[0] != "store" || segs[2] != "domain" {
+		http.NotFound(w, r)
+		return
+	}
+	domain := segs[3]
+	prefix := folder + "|" + domain + "|"
+	segs = segs[4:]
+
+	if len(segs) == 1 && segs[0] == "createCredentials" && r.Method == http.MethodPost {
+		body, _ := io.ReadAll(r.Body)
+		m := credentialID.FindStringSubmatch(string(body))
+		if m == nil {
+			http.Error(w, "No credential ID", http.StatusBadRequest)
+			return
+		}
+		if _, exists := s.credentials[prefix+m[1]]; exists {
+			http.Error(w, "A credential with the provided ID already exists", http.StatusConflict)
+			return
+		}
+		s.credentials[prefix+m[1]] = string(body)
+		return
+	}
+	if len(segs) < 3 || segs[0] != "credential" {
+		http.NotFound(w, r)
+		return
+	}
+	id, action := segs[1], strings.Join(segs[2:], "/")
+	c, ok := s.credentials[prefix+id]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch {
+	case action == "api/json" && r.Method == http.MethodGet:
+		class := strings.Trim(strings.Fields(strings.TrimSpace(c))[0], "<>")
+		store := folder
+		if store == "" {
+			store = "system"
+		}
+		writeJSON(w, map[string]string{
+			"id":          id,
+			"displayName": id,
+			"typeName":    class[strings.LastIndex(class, ".")+1:],
+			"fullName":    store + "/" + domain + "/" + id,
+		})
+	case action == "config.xml" && r.Method == http.MethodGet:
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = io.WriteString(w, credentialSecret.ReplaceAllString(c, "<$1>{AQAAABAAAAAQ}</"))
+	case action == "config.xml" && r.Method == http.MethodPost:
+		body, _ := io.ReadAll(r.Body)
+		delete(s.credentials, prefix+id)
+		if m := credentialID.FindStringSubmatch(string(body)); m != nil {
+			id = m[1]
+		}
+		s.credentials[prefix+id] = string(body)
+	case action == "doDelete" && r.Method == http.MethodPost:
+		delete(s.credentials, prefix+id)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,85 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// folderTree selects the fields of a folder returned by GetFolder.
+const folderTree = "name,fullName,url,displayName,description,healthReport[score,description],jobs[name]"
+
+// A Folder is the state of a folder as reported by the Jenkins API.
+type Folder struct {
+	Name         string         `json:"name"`
+	FullName     string         `json:"fullName"`
+	URL          string         `json:"url"`
+	DisplayName  string         `json:"displayName"`
+	Description  string         `json:"description"`
+	HealthReport []HealthReport `json:"healthReport"`
+	Jobs         []struct {
+		Name string `json:"name"`
+	} `json:"jobs"`
+}
+
+// GetFolder returns the folder with the supplied full name.
+func (c *jenkinsClient) GetFolder(ctx context.Context, fullName string) (*Folder, error) {
+	folder := &Folder{}
+	if err := c.getJSON(ctx, itemBase(fullName), url.Values{"tree": {folderTree}}, folder); err != nil {
+		return nil, err
+	}
+	return folder, nil
+}
+
+// GetFolderConfig returns the config.xml of the folder with the supplied full
+// name.
+func (c *jenkinsClient) GetFolderConfig(ctx context.Context, fullName string) (string, error) {
+	b, _, err := c.do(ctx, http.MethodGet, itemBase(fullName)+"/config.xml", nil, nil, "")
+	return string(b), err
+}
+
+// CreateFolder creates a folder with the supplied full name from a config.xml.
+// All parent folders must already exist.
+func (c *jenkinsClient) CreateFolder(ctx context.Context, fullName string, config string) error {
+	parent, name := splitFullName(fullName)
+	return c.postXML(ctx, itemBase(parent)+"/createItem", url.Values{"name": {name}}, config)
+}
+
+// UpdateFolderConfig replaces the config.xml of the folder with the supplied
+// full name.
+func (c *jenkinsClient) UpdateFolderConfig(ctx context.Context, fullName string, config string) error {
+	return c.postXML(ctx, itemBase(fullName)+"/config.xml", nil, config)
+}
+
+// DeleteFolder deletes the folder with the supplied full name along with
+// everything it contains.
+func (c *jenkinsClient) DeleteFolder(ctx context.Context, fullName string) error {
+	return c.post(ctx, itemBase(fullName)+"/doDelete", nil)
+}
+
+// A FolderComputation is the last scan of a computed folder, i.e. a
+// multibranch project or an organization folder, as reported by the Jenkins
+// API.
+type FolderComputation struct {
+	// Result is empty while the scan is running.
+	Result string `json:"result"`
+	// Timestamp is the start of the scan in milliseconds since the epoch.
+	Timestamp int64 `json:"timestamp"`
+}
+
+// GetFolderComputation returns the last scan of the computed folder with the
+// supplied full name. The scan of a multibranch project is named "indexing",
+// that of an organization folder "computation".
+func (c *jenkinsClient) GetFolderComputation(ctx context.Context, fullName string, computation string) (*FolderComputation, error) {
+	fc := &FolderComputation{}
+	if err := c.getJSON(ctx, itemBase(fullName)+"/"+computation, url.Values{"tree": {"result,timestamp"}}, fc); err != nil {
+		return nil, err
+	}
+	return fc, nil
+}
+
+// ScheduleFolderComputation schedules a scan of the computed folder with the
+// supplied full name.
+func (c *jenkinsClient) ScheduleFolderComputation(ctx context.Context, fullName string) error {
+	return c.post(ctx, itemBase(fullName)+"/build", url.Values{"delay": {"0"}})
+}
@@ -2,15 +2,15 @@ package clients
 
 import (
 	"context"
-	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/pkg/errors"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/provider-jenkins/apis/v1alpha1"
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	jenkins "github.com/bndr/gojenkins"
@@ -21,53 +21,171 @@ type Config struct {
 	BaseURL  string
 	Username string
 	Password string
+
+	// AuthMethod selects how requests are authenticated. Basic is used when
+	// it is empty.
+	AuthMethod v1alpha1.AuthMethod
+	// Token is the API token or bearer token used by the APIToken and
+	// Bearer methods.
+	Token string
+
+	// CABundle, ClientCert and ClientKey are PEM encoded.
+	CABundle           []byte
+	ClientCert         []byte
+	ClientKey          []byte
+	InsecureSkipVerify bool
 }
 
-// NewClient creates new Jenkins Client with provided Jenkins Configurations.
-func NewClient(c Config) *jenkins.Jenkins {
-	jenkins := jenkins.CreateJenkins(nil, c.BaseURL, c.Username, c.Password)
+// Client is the subset of the Jenkins API used by the controllers. Jobs and
+// folders are addressed by their full name, e.g. "folder/sub/job".
+type Client interface {
+	GetJob(ctx context.Context, fullName string) (*Job, error)
+	GetJobConfig(ctx context.Context, fullName string) (string, error)
+	CreateJob(ctx context.Context, fullName string, config string) error
+	UpdateJobConfig(ctx context.Context, fullName string, config string) error
+	DeleteJob(ctx context.Context, fullName string) error
+	RenameItem(ctx context.Context, fullName string, name string) error
+	MoveItem(ctx context.Context, fullName string, parent string) error
+
+	GetFolder(ctx context.Context, fullName string) (*Folder, error)
+	GetFolderConfig(ctx context.Context, fullName string) (string, error)
+	CreateFolder(ctx context.Context, fullName string, config string) error
+	UpdateFolderConfig(ctx context.Context, fullName string, config string) error
+	DeleteFolder(ctx context.Context, fullName string) error
+	GetFolderComputation(ctx context.Context, fullName string, computation string) (*FolderComputation, error)
+	ScheduleFolderComputation(ctx context.Context, fullName string) error
+
+	GetCredential(ctx context.Context, d CredentialDomain, id string) (*Credential, error)
+	GetCredentialConfig(ctx context.Context, d CredentialDomain, id string) (string, error)
+	CreateCredential(ctx context.Context, d CredentialDomain, config string) error
+	UpdateCredentialConfig(ctx context.Context, d CredentialDomain, id string, config string) error
+	DeleteCredential(ctx context.Context, d CredentialDomain, id string) error
+
+	GetServerInfo(ctx context.Context) (*ServerInfo, error)
+
+	GetNode(ctx context.Context, name string) (*jenkins.NodeResponse, error)
+	CreateNode(ctx context.Context, name string, numExecutors int, description string, remoteFS string, label string) error
+	GetNodeConfig(ctx context.Context, name string) (string, error)
+	UpdateNodeConfig(ctx context.Context, name string, config string) error
+	ToggleNodeOffline(ctx context.Context, name string, message string) error
+	ChangeNodeOfflineCause(ctx context.Context, name string, message string) error
+	GetNodeAgent(ctx context.Context, name string) (*Agent, error)
+	DeleteNode(ctx context.Context, name string) error
+}
 
-	_, err := jenkins.Init(context.Background())
+// NewClient creates new Jenkins Client with provided Jenkins Configurations.
+func NewClient(c Config) (Client, error) {
+	t, err := newTransport(c)
 	if err != nil {
-		fmt.Print("Something Went Wrong")
+		return nil, err
 	}
-	return jenkins
+	// Requests are authenticated by the transport rather than by the basic
+	// auth of gojenkins, which supports neither tokens nor client
+	// certificates.
+	hc := &http.Client{Transport: newAuthTransport(c, t), Timeout: requestTimeout}
+	return &jenkinsClient{
+		baseURL:   strings.TrimSuffix(c.BaseURL, "/"),
+		http:      hc,
+		requester: jenkins.CreateJenkins(hc, c.BaseURL).Requester,
+		// Jenkins does not require a crumb for requests authenticated with
+		// an API token.
+		useCrumb: c.AuthMethod != v1alpha1.AuthMethodAPIToken,
+	}, nil
 }
 
-// GetConfig constructs a Config that can be used to authenticate to Jenkins
-func GetConfig(ctx context.Context, c client.Client, mg resource.Managed) (*Config, error) {
-	switch {
-	case mg.GetProviderConfigReference() != nil:
-		return UseProviderConfig(ctx, c, mg)
-	default:
-		return nil, errors.New("providerConfigRef is not given")
-	}
+// jenkinsClient implements Client on top of the gojenkins requester. The
+// response types of gojenkins are reused to decode API responses.
+type jenkinsClient struct {
+	baseURL   string
+	http      *http.Client
+	requester *jenkins.Requester
+	useCrumb  bool
 }
 
-// UseProviderConfig to produce a config that can be used to authenticate to AWS.
-func UseProviderConfig(ctx context.Context, c client.Client, mg resource.Managed) (*Config, error) {
-	pc := &v1alpha1.ProviderConfig{}
-	if err := c.Get(ctx, types.NamespacedName{Name: mg.GetProviderConfigReference().Name}, pc); err != nil {
-		return nil, errors.Wrap(err, "cannot get referenced Provider")
+// ResolveConfig produces a Config from the supplied ProviderConfig by reading
+// the credentials and TLS material it references.
+func ResolveConfig(ctx context.Context, c client.Client, pc *v1alpha1.ProviderConfig) (*Config, error) {
+	cfg := &Config{BaseURL: pc.Spec.BaseURL, Username: pc.Spec.Username, AuthMethod: v1alpha1.AuthMethodBasic}
+	if err := useTLSConfig(ctx, c, pc.Spec.TLS, cfg); err != nil {
+		return nil, err
 	}
 
-	t := resource.NewProviderConfigUsageTracker(c, &v1alpha1.ProviderConfigUsage{})
-	if err := t.Track(ctx, mg); err != nil {
-		return nil, errors.Wrap(err, "cannot track ProviderConfig usage")
+	pcc := pc.Spec.Credentials
+	data, err := extractCredentials(ctx, c, pcc)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get credentials")
+	}
+	creds, err := parseCredentials(data)
+	if err != nil {
+		return nil, err
+	}
+	if creds.URL != "" {
+		cfg.BaseURL = creds.URL
+	}
+	if creds.Username != "" {
+		cfg.Username = creds.Username
 	}
+	secret := creds.Token
 
-	switch s := pc.Spec.Credentials.Source; s { //nolint:exhaustive
-	case xpv1.CredentialsSourceSecret:
-		csr := pc.Spec.Credentials.SecretRef
-		if csr == nil {
-			return nil, errors.New("no credentials secret referenced")
+	// The pod's service account token can only be used as a bearer token.
+	if pcc.Source == xpv1.CredentialsSourceInjectedIdentity {
+		cfg.AuthMethod = v1alpha1.AuthMethodBearer
+	}
+
+	auth := pc.Spec.Auth
+	if auth != nil && auth.Method != "" {
+		cfg.AuthMethod = auth.Method
+	}
+	if auth != nil && auth.UsernameSecretRef != nil {
+		u, err := resource.ExtractSecret(ctx, c, xpv1.CommonCredentialSelectors{SecretRef: auth.UsernameSecretRef})
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot get username secret")
 		}
-		s := &corev1.Secret{}
-		if err := c.Get(ctx, types.NamespacedName{Namespace: csr.Namespace, Name: csr.Name}, s); err != nil {
-			return nil, errors.Wrap(err, "cannot get credentials secret")
+		cfg.Username = string(u)
+	}
+	if auth != nil && auth.TokenSecretRef != nil {
+		t, err := resource.ExtractSecret(ctx, c, xpv1.CommonCredentialSelectors{SecretRef: auth.TokenSecretRef})
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot get token secret")
 		}
-		return &Config{BaseURL: pc.Spec.BaseURL, Username: pc.Spec.Username, Password: string(s.Data[csr.Key])}, nil
+		secret = string(t)
+	}
+
+	if cfg.BaseURL == "" {
+		return nil, errors.New("no Jenkins URL configured: set spec.baseurl or the url of the credentials")
+	}
+
+	switch cfg.AuthMethod {
+	case v1alpha1.AuthMethodBasic:
+		cfg.Password = secret
+	case v1alpha1.AuthMethodAPIToken, v1alpha1.AuthMethodBearer:
+		cfg.Token = secret
 	default:
-		return nil, errors.Errorf("credentials source %s is not currently supported", s)
+		return nil, errors.Errorf("authentication method %s is not supported", cfg.AuthMethod)
+	}
+	return cfg, nil
+}
+
+// ServerInfo describes a Jenkins instance as seen by the authenticated user.
+type ServerInfo struct {
+	Version string
+	User    string
+}
+
+// GetServerInfo returns the version of Jenkins and the name of the user
+// requests are authenticated as.
+func (c *jenkinsClient) GetServerInfo(ctx context.Context) (*ServerInfo, error) {
+	// Only the X-Jenkins header is needed, so the tree selects a single field
+	// rather than every top level job and view.
+	_, h, err := c.do(ctx, http.MethodGet, "/api/json", url.Values{"tree": {"mode"}}, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	who := struct {
+		Name string `json:"name"`
+	}{}
+	if err := c.getJSON(ctx, "/whoAmI", nil, &who); err != nil {
+		return nil, err
 	}
+	return &ServerInfo{Version: h.Get("X-Jenkins"), User: who.Name}, nil
 }
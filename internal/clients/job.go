@@ -0,0 +1,115 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// jobTree selects the fields of a job returned by GetJob. Using a tree lets
+// Jenkins include the details of the referenced builds in a single response.
+const jobTree = "name,fullName,url,buildable,disabled,color," +
+	"healthReport[score,description]," +
+	"lastBuild[number,result,timestamp]," +
+	"lastSuccessfulBuild[number,result,timestamp]," +
+	"lastFailedBuild[number,result,timestamp]"
+
+// A Job is the state of a job as reported by the Jenkins API.
+type Job struct {
+	Name                string         `json:"name"`
+	FullName            string         `json:"fullName"`
+	URL                 string         `json:"url"`
+	Buildable           bool           `json:"buildable"`
+	Disabled            bool           `json:"disabled"`
+	Color               string         `json:"color"`
+	HealthReport        []HealthReport `json:"healthReport"`
+	LastBuild           *Build         `json:"lastBuild"`
+	LastSuccessfulBuild *Build         `json:"lastSuccessfulBuild"`
+	LastFailedBuild     *Build         `json:"lastFailedBuild"`
+}
+
+// A HealthReport summarises the health of a job.
+type HealthReport struct {
+	Score       int64  `json:"score"`
+	Description string `json:"description"`
+}
+
+// A Build is a build of a job. Timestamp is in milliseconds since the epoch
+// and Result is empty while the build is running.
+type Build struct {
+	Number    int64  `json:"number"`
+	Result    string `json:"result"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// splitFullName splits a full item name such as "folder/sub/job" into the full
+// name of its parent folder and the item name.
+func splitFullName(fullName string) (string, string) {
+	fullName = strings.Trim(fullName, "/")
+	i := strings.LastIndex(fullName, "/")
+	if i < 0 {
+		return "", fullName
+	}
+	return fullName[:i], fullName[i+1:]
+}
+
+// itemBase returns the API path of the item with the supplied full name. The
+// root of Jenkins has the empty full name.
+func itemBase(fullName string) string {
+	fullName = strings.Trim(fullName, "/")
+	if fullName == "" {
+		return ""
+	}
+	segs := strings.Split(fullName, "/")
+	for i := range segs {
+		segs[i] = url.PathEscape(segs[i])
+	}
+	return "/job/" + strings.Join(segs, "/job/")
+}
+
+// GetJob returns the job with the supplied full name.
+func (c *jenkinsClient) GetJob(ctx context.Context, fullName string) (*Job, error) {
+	job := &Job{}
+	if err := c.getJSON(ctx, itemBase(fullName), url.Values{"tree": {jobTree}}, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// GetJobConfig returns the config.xml of the job with the supplied full name.
+func (c *jenkinsClient) GetJobConfig(ctx context.Context, fullName string) (string, error) {
+	b, _, err := c.do(ctx, http.MethodGet, itemBase(fullName)+"/config.xml", nil, nil, "")
+	return string(b), err
+}
+
+// CreateJob creates a job with the supplied full name from a config.xml. All
+// parent folders must already exist.
+func (c *jenkinsClient) CreateJob(ctx context.Context, fullName string, config string) error {
+	parent, name := splitFullName(fullName)
+	return c.postXML(ctx, itemBase(parent)+"/createItem", url.Values{"name": {name}}, config)
+}
+
+// UpdateJobConfig replaces the config.xml of the job with the supplied full
+// name.
+func (c *jenkinsClient) UpdateJobConfig(ctx context.Context, fullName string, config string) error {
+	return c.postXML(ctx, itemBase(fullName)+"/config.xml", nil, config)
+}
+
+// DeleteJob deletes the job with the supplied full name.
+func (c *jenkinsClient) DeleteJob(ctx context.Context, fullName string) error {
+	return c.post(ctx, itemBase(fullName)+"/doDelete", nil)
+}
+
+// RenameItem renames the job or folder with the supplied full name within its
+// parent folder.
+func (c *jenkinsClient) RenameItem(ctx context.Context, fullName string, name string) error {
+	return c.post(ctx, itemBase(fullName)+"/confirmRename", url.Values{"newName": {name}})
+}
+
+// MoveItem moves the job or folder with the supplied full name into the parent
+// folder with the supplied full name. The empty name moves it to the root of
+// Jenkins. Moving items requires the Folders plugin.
+func (c *jenkinsClient) MoveItem(ctx context.Context, fullName string, parent string) error {
+	return c.post(ctx, itemBase(fullName)+"/move/move", url.Values{"destination": {"/" + strings.Trim(parent, "/")}})
+}
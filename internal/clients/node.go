@@ -0,0 +1,117 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	jenkins "github.com/bndr/gojenkins"
+	"github.com/pkg/errors"
+)
+
+const nodeType = "hudson.slaves.DumbSlave$DescriptorImpl"
+
+// nodeBase returns the API path of the node with the supplied name.
+func nodeBase(name string) string {
+	return "/computer/" + url.PathEscape(name)
+}
+
+// GetNode returns the node with the supplied name. Jenkins only reports what
+// each executor is running at depth 1; at depth 0 executors are empty objects.
+func (c *jenkinsClient) GetNode(ctx context.Context, name string) (*jenkins.NodeResponse, error) {
+	node := &jenkins.NodeResponse{}
+	if err := c.getJSON(ctx, nodeBase(name), url.Values{"depth": {"1"}}, node); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// CreateNode creates a permanent node using the default JNLP launcher.
+func (c *jenkinsClient) CreateNode(ctx context.Context, name string, numExecutors int, description string, remoteFS string, label string) error {
+	return c.post(ctx, "/computer/doCreateItem", url.Values{
+		"name": {name},
+		"type": {nodeType},
+		"json": {makeJSON(map[string]interface{}{
+			"name":              name,
+			"nodeDescription":   description,
+			"remoteFS":          remoteFS,
+			"numExecutors":      numExecutors,
+			"mode":              "NORMAL",
+			"type":              nodeType,
+			"labelString":       label,
+			"retentionStrategy": map[string]string{"stapler-class": "hudson.slaves.RetentionStrategy$Always"},
+			"nodeProperties":    map[string]string{"stapler-class-bag": "true"},
+			"launcher":          map[string]string{"stapler-class": "hudson.slaves.JNLPLauncher"},
+		})},
+	})
+}
+
+// GetNodeConfig returns the config.xml of the node with the supplied name.
+func (c *jenkinsClient) GetNodeConfig(ctx context.Context, name string) (string, error) {
+	b, _, err := c.do(ctx, http.MethodGet, nodeBase(name)+"/config.xml", nil, nil, "")
+	return string(b), err
+}
+
+// UpdateNodeConfig replaces the config.xml of the node with the supplied name.
+func (c *jenkinsClient) UpdateNodeConfig(ctx context.Context, name string, config string) error {
+	return c.postXML(ctx, nodeBase(name)+"/config.xml", nil, config)
+}
+
+// ToggleNodeOffline marks the node with the supplied name temporarily offline
+// with the supplied message, or back online if it is temporarily offline.
+func (c *jenkinsClient) ToggleNodeOffline(ctx context.Context, name string, message string) error {
+	return c.post(ctx, nodeBase(name)+"/toggleOffline", url.Values{"offlineMessage": {message}})
+}
+
+// ChangeNodeOfflineCause changes the message of the node with the supplied
+// name, which must be temporarily offline.
+func (c *jenkinsClient) ChangeNodeOfflineCause(ctx context.Context, name string, message string) error {
+	return c.post(ctx, nodeBase(name)+"/changeOfflineCause", url.Values{"offlineMessage": {message}})
+}
+
+// An Agent describes how an inbound agent connects to Jenkins.
+type Agent struct {
+	// Secret authenticates the agent as the node.
+	Secret string
+	// URL of Jenkins as seen by the agent.
+	URL string
+}
+
+// GetNodeAgent returns the connection details of the inbound agent of the
+// node with the supplied name. The node must use the inbound agent launcher.
+func (c *jenkinsClient) GetNodeAgent(ctx context.Context, name string) (*Agent, error) {
+	b, _, err := c.do(ctx, http.MethodGet, nodeBase(name)+"/slave-agent.jnlp", nil, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	jnlp := struct {
+		Arguments []string `xml:"application-desc>argument"`
+	}{}
+	if err := DecodeXML(string(b), &jnlp); err != nil {
+		return nil, err
+	}
+	if len(jnlp.Arguments) == 0 {
+		return nil, errors.Errorf("no agent secret in slave-agent.jnlp of node %s", name)
+	}
+	// The arguments are the secret and the node name, followed by options.
+	agent := &Agent{Secret: jnlp.Arguments[0], URL: c.baseURL + "/"}
+	for i := 1; i < len(jnlp.Arguments)-1; i++ {
+		if jnlp.Arguments[i] == "-url" {
+			agent.URL = jnlp.Arguments[i+1]
+		}
+	}
+	return agent, nil
+}
+
+// DeleteNode deletes the node with the supplied name.
+func (c *jenkinsClient) DeleteNode(ctx context.Context, name string) error {
+	return c.post(ctx, nodeBase(name)+"/doDelete", nil)
+}
+
+// makeJSON encodes the structured form data Jenkins expects in the json
+// parameter of form submissions.
+func makeJSON(v interface{}) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
@@ -0,0 +1,132 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	jenkins "github.com/bndr/gojenkins"
+	"github.com/pkg/errors"
+)
+
+const (
+	crumbIssuerPath = "/crumbIssuer"
+	apiJSONSuffix   = "api/json"
+
+	contentTypeForm = "application/x-www-form-urlencoded"
+	contentTypeXML  = "application/xml"
+
+	// requestTimeout bounds requests to Jenkins. The gojenkins requester does
+	// not pass the context of a request on to the HTTP client.
+	requestTimeout = 1 * time.Minute
+)
+
+// do sends a request to the Jenkins API through the gojenkins requester and
+// returns the response body and headers. Responses with an error status are
+// returned as an *APIError.
+func (c *jenkinsClient) do(ctx context.Context, method string, endpoint string, query url.Values, body io.Reader, contentType string) ([]byte, http.Header, error) {
+	ar := jenkins.NewAPIRequest(method, endpoint, body)
+	// Like gojenkins, address the JSON API as a suffix of the endpoint.
+	if strings.HasSuffix(endpoint, "/"+apiJSONSuffix) {
+		ar.Endpoint, ar.Suffix = strings.TrimSuffix(endpoint, apiJSONSuffix), apiJSONSuffix
+	}
+	if contentType != "" {
+		ar.SetHeader("Content-Type", contentType)
+	}
+	if method == http.MethodPost && c.useCrumb {
+		if err := c.setCrumb(ctx, ar); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	raw := ""
+	rsp, err := c.requester.Do(ctx, ar, &raw, querystring(query))
+	if err != nil {
+		return nil, nil, requestError(method, endpoint, err)
+	}
+	if rsp.StatusCode >= http.StatusBadRequest {
+		return nil, nil, newAPIError(method, endpoint, rsp, []byte(raw))
+	}
+	return []byte(raw), rsp.Header, nil
+}
+
+// requestError classifies an error returned by the gojenkins requester. It
+// returns the X-Error header Jenkins sets on failed submissions as a plain
+// error, without the response it was set on.
+func requestError(method string, endpoint string, err error) error {
+	var ue *url.Error
+	if errors.As(err, &ue) {
+		return errors.Wrapf(err, "cannot send %s %s", method, endpoint)
+	}
+	return newMessageError(method, endpoint, err.Error())
+}
+
+// querystring converts the supplied query to the form accepted by the
+// gojenkins requester, which supports a single value per parameter.
+func querystring(query url.Values) map[string]string {
+	qs := make(map[string]string, len(query))
+	for k := range query {
+		qs[k] = query.Get(k)
+	}
+	return qs
+}
+
+// setCrumb adds a CSRF crumb to a request when the Jenkins crumb issuer is
+// enabled. Unlike the requester's SetCrumb it reports failures and forwards
+// all cookies of the session that issued the crumb.
+func (c *jenkinsClient) setCrumb(ctx context.Context, ar *jenkins.APIRequest) error {
+	crumb := struct {
+		Crumb             string `json:"crumb"`
+		CrumbRequestField string `json:"crumbRequestField"`
+	}{}
+	rsp, err := c.requester.GetJSON(ctx, crumbIssuerPath, &crumb, nil)
+	if err != nil {
+		return requestError(http.MethodGet, crumbIssuerPath, err)
+	}
+
+	switch {
+	case rsp.StatusCode == http.StatusNotFound:
+		// CSRF protection is disabled.
+		return nil
+	case rsp.StatusCode >= http.StatusBadRequest:
+		return newAPIError(http.MethodGet, crumbIssuerPath, rsp, nil)
+	}
+
+	if crumb.CrumbRequestField != "" {
+		ar.SetHeader(crumb.CrumbRequestField, crumb.Crumb)
+	}
+	// Crumbs are bound to the web session that issued them.
+	cookies := make([]string, 0, len(rsp.Cookies()))
+	for _, ck := range rsp.Cookies() {
+		cookies = append(cookies, (&http.Cookie{Name: ck.Name, Value: ck.Value}).String())
+	}
+	if len(cookies) > 0 {
+		ar.SetHeader("Cookie", strings.Join(cookies, "; "))
+	}
+	return nil
+}
+
+// getJSON decodes the JSON API of the supplied endpoint into v.
+func (c *jenkinsClient) getJSON(ctx context.Context, endpoint string, query url.Values, v interface{}) error {
+	b, _, err := c.do(ctx, http.MethodGet, strings.TrimSuffix(endpoint, "/"+apiJSONSuffix)+"/"+apiJSONSuffix, query, nil, "")
+	if err != nil {
+		return err
+	}
+	return errors.Wrapf(json.Unmarshal(b, v), "cannot decode response of %s", endpoint)
+}
+
+// post sends a form POST to the supplied endpoint.
+func (c *jenkinsClient) post(ctx context.Context, endpoint string, query url.Values) error {
+	_, _, err := c.do(ctx, http.MethodPost, endpoint, query, nil, contentTypeForm)
+	return err
+}
+
+// postXML sends an XML document to the supplied endpoint.
+func (c *jenkinsClient) postXML(ctx context.Context, endpoint string, query url.Values, xml string) error {
+	_, _, err := c.do(ctx, http.MethodPost, endpoint, query, strings.NewReader(xml), contentTypeXML)
+	return err
+}
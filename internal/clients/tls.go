@@ -0,0 +1,106 @@
+package clients
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-jenkins/apis/v1alpha1"
+)
+
+// newTransport returns the transport used to connect to Jenkins, configured
+// with the TLS settings of the supplied Config.
+func newTransport(c Config) (http.RoundTripper, error) {
+	if c.CABundle == nil && c.ClientCert == nil && c.ClientKey == nil && !c.InsecureSkipVerify {
+		return http.DefaultTransport, nil
+	}
+
+	cfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: c.InsecureSkipVerify, //nolint:gosec // Explicitly requested by the ProviderConfig.
+	}
+
+	if c.CABundle != nil {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(c.CABundle) {
+			return nil, errors.New("cannot parse CA bundle: no PEM encoded certificates found")
+		}
+		cfg.RootCAs = pool
+	}
+
+	if c.ClientCert != nil || c.ClientKey != nil {
+		cert, err := tls.X509KeyPair(c.ClientCert, c.ClientKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot load client certificate")
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.TLSClientConfig = cfg
+	return t, nil
+}
+
+// useTLSConfig resolves the TLS settings of a ProviderConfig into cfg.
+func useTLSConfig(ctx context.Context, c client.Client, t *v1alpha1.TLSConfig, cfg *Config) error {
+	if t == nil {
+		return nil
+	}
+	cfg.InsecureSkipVerify = t.InsecureSkipVerify
+
+	for _, d := range []struct {
+		sel  *v1alpha1.DataKeySelector
+		into *[]byte
+		name string
+	}{
+		{sel: t.CABundle, into: &cfg.CABundle, name: "CA bundle"},
+		{sel: t.ClientCert, into: &cfg.ClientCert, name: "client certificate"},
+		{sel: t.ClientKey, into: &cfg.ClientKey, name: "client key"},
+	} {
+		if d.sel == nil {
+			continue
+		}
+		b, err := extractDataKey(ctx, c, *d.sel)
+		if err != nil {
+			return errors.Wrapf(err, "cannot get TLS %s", d.name)
+		}
+		*d.into = b
+	}
+	return nil
+}
+
+// extractDataKey returns the data of the Secret or ConfigMap key selected by
+// the supplied DataKeySelector.
+func extractDataKey(ctx context.Context, c client.Client, sel v1alpha1.DataKeySelector) ([]byte, error) {
+	switch {
+	case sel.SecretKeyRef != nil:
+		return resource.ExtractSecret(ctx, c, xpv1.CommonCredentialSelectors{SecretRef: sel.SecretKeyRef})
+	case sel.ConfigMapKeyRef != nil:
+		ref := sel.ConfigMapKeyRef
+		cm := &corev1.ConfigMap{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, cm); err != nil {
+			return nil, errors.Wrap(err, "cannot get ConfigMap")
+		}
+		if v, ok := cm.Data[ref.Key]; ok {
+			return []byte(v), nil
+		}
+		if v, ok := cm.BinaryData[ref.Key]; ok {
+			return v, nil
+		}
+		return nil, errors.Errorf("ConfigMap %s/%s has no key %s", ref.Namespace, ref.Name, ref.Key)
+	default:
+		return nil, errors.New("neither secretKeyRef nor configMapKeyRef is set")
+	}
+}
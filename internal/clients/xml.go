@@ -0,0 +1,86 @@
+package clients
+
+import (
+	"encoding/xml"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// pluginAttr is the attribute Jenkins adds to elements contributed by a
+// plugin, e.g. plugin="workflow-job@1254.v3f64639b_11dd".
+const pluginAttr = "plugin"
+
+// declaration matches the XML declaration. Jenkins stores config.xml as XML
+// 1.1, which encoding/xml refuses to decode.
+var declaration = regexp.MustCompile(`^\s*<\?xml[^>]*\?>`)
+
+// EqualXML reports whether the supplied config.xml documents are semantically
+// equal. Whitespace between elements, attribute order, comments and the XML
+// declaration are ignored, as are plugin attributes if ignorePlugins is true.
+// Documents that cannot be parsed are compared as strings.
+func EqualXML(a, b string, ignorePlugins bool) bool {
+	ca, err := canonicalXML(a, ignorePlugins)
+	if err != nil {
+		return a == b
+	}
+	cb, err := canonicalXML(b, ignorePlugins)
+	if err != nil {
+		return a == b
+	}
+	return ca == cb
+}
+
+// DecodeXML decodes the supplied config.xml document into v. Unlike
+// xml.Unmarshal it accepts the XML 1.1 declaration used by Jenkins.
+func DecodeXML(doc string, v interface{}) error {
+	return errors.Wrap(xml.Unmarshal([]byte(declaration.ReplaceAllString(doc, "")), v), "cannot decode config.xml")
+}
+
+// canonicalXML renders the supplied document in a canonical form suitable for
+// comparison.
+func canonicalXML(doc string, ignorePlugins bool) (string, error) {
+	d := xml.NewDecoder(strings.NewReader(declaration.ReplaceAllString(doc, "")))
+	out := &strings.Builder{}
+	for {
+		t, err := d.Token()
+		if err == io.EOF {
+			return out.String(), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		switch t := t.(type) {
+		case xml.StartElement:
+			out.WriteString("<" + qualified(t.Name))
+			attrs := make([]xml.Attr, 0, len(t.Attr))
+			for _, a := range t.Attr {
+				if ignorePlugins && a.Name.Space == "" && a.Name.Local == pluginAttr {
+					continue
+				}
+				attrs = append(attrs, a)
+			}
+			sort.Slice(attrs, func(i, j int) bool { return qualified(attrs[i].Name) < qualified(attrs[j].Name) })
+			for _, a := range attrs {
+				out.WriteString(" " + qualified(a.Name) + "=\"")
+				_ = xml.EscapeText(out, []byte(a.Value))
+				out.WriteString("\"")
+			}
+			out.WriteString(">")
+		case xml.EndElement:
+			out.WriteString("</" + qualified(t.Name) + ">")
+		case xml.CharData:
+			_ = xml.EscapeText(out, []byte(strings.TrimSpace(string(t))))
+		}
+	}
+}
+
+func qualified(n xml.Name) string {
+	if n.Space == "" {
+		return n.Local
+	}
+	return n.Space + ":" + n.Local
+}
@@ -0,0 +1,101 @@
+package clients
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEqualXML(t *testing.T) {
+	type args struct {
+		a             string
+		b             string
+		ignorePlugins bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   bool
+	}{
+		"Identical": {
+			reason: "Identical documents should be equal.",
+			args:   args{a: `<project><description>a</description></project>`, b: `<project><description>a</description></project>`},
+			want:   true,
+		},
+		"AttributeOrder": {
+			reason: "The order of attributes should be ignored.",
+			args:   args{a: `<definition class="cps" plugin="workflow@1"/>`, b: `<definition plugin="workflow@1" class="cps"/>`},
+			want:   true,
+		},
+		"Whitespace": {
+			reason: "Whitespace between elements and around text should be ignored.",
+			args:   args{a: "<project>\n  <description> a </description>\n</project>\n", b: `<project><description>a</description></project>`},
+			want:   true,
+		},
+		"Comments": {
+			reason: "Comments should be ignored.",
+			args:   args{a: `<project><!-- managed by Crossplane --><description>a</description></project>`, b: `<project><description>a</description></project>`},
+			want:   true,
+		},
+		"Declaration": {
+			reason: "The XML 1.1 declaration used by Jenkins should be ignored.",
+			args:   args{a: "<?xml version='1.1' encoding='UTF-8'?>\n<project/>", b: `<project></project>`},
+			want:   true,
+		},
+		"Escaping": {
+			reason: "Equivalent escaping of text should be ignored.",
+			args:   args{a: `<script>echo "a" &amp;&amp; true</script>`, b: `<script>echo &quot;a&quot; &amp;&amp; true</script>`},
+			want:   true,
+		},
+		"DifferentText": {
+			reason: "Documents whose text differs should not be equal.",
+			args:   args{a: `<project><description>a</description></project>`, b: `<project><description>b</description></project>`},
+			want:   false,
+		},
+		"DifferentAttribute": {
+			reason: "Documents whose attributes differ should not be equal.",
+			args:   args{a: `<definition class="cps"/>`, b: `<definition class="scm"/>`},
+			want:   false,
+		},
+		"ElementOrder": {
+			reason: "The order of elements is significant.",
+			args:   args{a: `<project><a/><b/></project>`, b: `<project><b/><a/></project>`},
+			want:   false,
+		},
+		"PluginVersionIgnored": {
+			reason: "Plugin attributes should be ignored if ignorePlugins is true.",
+			args:   args{a: `<flow-definition plugin="workflow-job@1.0"/>`, b: `<flow-definition plugin="workflow-job@2.0"/>`, ignorePlugins: true},
+			want:   true,
+		},
+		"PluginMissingIgnored": {
+			reason: "A missing plugin attribute should be ignored if ignorePlugins is true.",
+			args:   args{a: `<flow-definition/>`, b: `<flow-definition plugin="workflow-job@2.0"/>`, ignorePlugins: true},
+			want:   true,
+		},
+		"PluginVersionCompared": {
+			reason: "Plugin attributes should be compared if ignorePlugins is false.",
+			args:   args{a: `<flow-definition plugin="workflow-job@1.0"/>`, b: `<flow-definition plugin="workflow-job@2.0"/>`},
+			want:   false,
+		},
+		"Unparseable": {
+			reason: "Documents that cannot be parsed should be compared as strings.",
+			args:   args{a: `<project>`, b: `<project>`},
+			want:   true,
+		},
+		"UnparseableDifferent": {
+			reason: "Documents that cannot be parsed should not be equal to a different document.",
+			args:   args{a: `<project>`, b: `<project></project>`},
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := EqualXML(tc.args.a, tc.args.b, tc.args.ignorePlugins)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nEqualXML(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,398 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package computedfolder
+
+import (
+	"encoding/xml"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/provider-jenkins/apis/dashboard/v1alpha1"
+	"github.com/crossplane/provider-jenkins/internal/clients"
+)
+
+const (
+	orphanedItemStrategyClass   = "com.cloudbees.hudson.plugins.folder.computed.DefaultOrphanedItemStrategy"
+	branchPropertyStrategyClass = "jenkins.branch.DefaultBranchPropertyStrategy"
+	headFilterTraitClass        = "jenkins.scm.impl.trait.WildcardSCMHeadFilterTrait"
+	sourceFilterTraitClass      = "jenkins.scm.impl.trait.WildcardSCMSourceFilterTrait"
+)
+
+// ElementName returns the name of an element whose name is the supplied class.
+// XStream escapes underscores in element names, but not in class attributes.
+func ElementName(class string) string {
+	return strings.ReplaceAll(class, "_", "__")
+}
+
+// An Owner is a reference from an element of the config.xml of a computed
+// folder back to the folder. XStream serializes it as a path relative to the
+// element, so it is written but never decoded.
+type Owner struct {
+	Class string
+}
+
+// MarshalXML writes a reference to the root element, which is the parent of
+// the element containing the owner.
+func (o Owner) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Attr = append(start.Attr,
+		xml.Attr{Name: xml.Name{Local: "class"}, Value: o.Class},
+		xml.Attr{Name: xml.Name{Local: "reference"}, Value: "../.."})
+	return e.EncodeElement("", start)
+}
+
+// UnmarshalXML skips the owner.
+func (o *Owner) UnmarshalXML(d *xml.Decoder, _ xml.StartElement) error {
+	return d.Skip()
+}
+
+// A BranchPropertyStrategy is the strategy element of a branch source or an
+// organization folder. Branch properties are not managed, so the default
+// strategy is written and the observed strategy is never decoded.
+type BranchPropertyStrategy struct{}
+
+// MarshalXML writes the default strategy, which adds no branch properties.
+func (BranchPropertyStrategy) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "class"}, Value: branchPropertyStrategyClass})
+	properties := struct {
+		Class string `xml:"class,attr"`
+	}{Class: "empty-list"}
+	return e.EncodeElement(struct {
+		Properties interface{} `xml:"properties"`
+	}{Properties: properties}, start)
+}
+
+// UnmarshalXML skips the strategy.
+func (*BranchPropertyStrategy) UnmarshalXML(d *xml.Decoder, _ xml.StartElement) error {
+	return d.Skip()
+}
+
+// Properties is the properties element of a computed folder. Properties are
+// not managed, but other managed resources contribute them, e.g. the folder
+// scoped credentials of Credentials. The observed properties are therefore
+// written back unchanged, and never decoded.
+type Properties struct {
+	Inner string
+}
+
+// MarshalXML writes the properties verbatim.
+func (p Properties) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(struct {
+		Inner string `xml:",innerxml"`
+	}{Inner: p.Inner}, start)
+}
+
+// UnmarshalXML skips the properties.
+func (*Properties) UnmarshalXML(d *xml.Decoder, _ xml.StartElement) error {
+	return d.Skip()
+}
+
+// ObservedProperties returns the properties of the supplied config.xml of a
+// computed folder.
+func ObservedProperties(doc string) (Properties, error) {
+	cfg := struct {
+		Properties struct {
+			Inner string `xml:",innerxml"`
+		} `xml:"properties"`
+	}{}
+	err := clients.DecodeXML(doc, &cfg)
+	return Properties{Inner: cfg.Properties.Inner}, err
+}
+
+// OrphanedItemStrategy is the orphanedItemStrategy element of a computed
+// folder.
+type OrphanedItemStrategy struct {
+	Class             string `xml:"class,attr"`
+	PruneDeadBranches bool   `xml:"pruneDeadBranches"`
+	DaysToKeep        int64  `xml:"daysToKeep"`
+	NumToKeep         int64  `xml:"numToKeep"`
+	AbortBuilds       bool   `xml:"abortBuilds"`
+}
+
+// GenerateOrphanedItemStrategy produces the orphanedItemStrategy element of
+// the supplied strategy. Orphaned items are discarded immediately if it is
+// nil. Jenkins uses -1 for limits that are not set.
+func GenerateOrphanedItemStrategy(s *v1alpha1.OrphanedItemStrategy) OrphanedItemStrategy {
+	o := OrphanedItemStrategy{Class: orphanedItemStrategyClass, PruneDeadBranches: true, DaysToKeep: -1, NumToKeep: -1}
+	if s == nil {
+		return o
+	}
+	o.PruneDeadBranches = s.DiscardOldItems == nil || *s.DiscardOldItems
+	o.AbortBuilds = s.AbortBuilds
+	if o.PruneDeadBranches && s.DaysToKeep != nil {
+		o.DaysToKeep = *s.DaysToKeep
+	}
+	if o.PruneDeadBranches && s.NumToKeep != nil {
+		o.NumToKeep = *s.NumToKeep
+	}
+	return o
+}
+
+// Triggers is the triggers element of a computed folder.
+type Triggers struct {
+	Periodic *PeriodicFolderTrigger `xml:"com.cloudbees.hudson.plugins.folder.computed.PeriodicFolderTrigger"`
+}
+
+// A PeriodicFolderTrigger scans a computed folder if it was not scanned for
+// the supplied interval in milliseconds. The spec determines how often the
+// interval is checked.
+type PeriodicFolderTrigger struct {
+	Spec     string `xml:"spec"`
+	Interval int64  `xml:"interval"`
+}
+
+// scanIntervals are the scan intervals offered by Jenkins.
+var scanIntervals = map[string]time.Duration{
+	"1m":  time.Minute,
+	"2m":  2 * time.Minute,
+	"5m":  5 * time.Minute,
+	"10m": 10 * time.Minute,
+	"15m": 15 * time.Minute,
+	"20m": 20 * time.Minute,
+	"25m": 25 * time.Minute,
+	"30m": 30 * time.Minute,
+	"1h":  time.Hour,
+	"2h":  2 * time.Hour,
+	"4h":  4 * time.Hour,
+	"8h":  8 * time.Hour,
+	"12h": 12 * time.Hour,
+	"1d":  24 * time.Hour,
+	"2d":  48 * time.Hour,
+	"1w":  7 * 24 * time.Hour,
+	"2w":  14 * 24 * time.Hour,
+	"4w":  28 * 24 * time.Hour,
+}
+
+// GenerateTriggers produces the triggers element of the supplied scan
+// interval. The folder is not scanned periodically if it is empty.
+func GenerateTriggers(interval string) (Triggers, error) {
+	if interval == "" {
+		return Triggers{}, nil
+	}
+	d, ok := scanIntervals[interval]
+	if !ok {
+		return Triggers{}, errors.Errorf("unknown scan interval %q", interval)
+	}
+	return Triggers{Periodic: &PeriodicFolderTrigger{Spec: crontab(d), Interval: d.Milliseconds()}}, nil
+}
+
+// crontab returns the spec Jenkins uses to check the supplied interval, which
+// is checked more often the shorter it is.
+func crontab(d time.Duration) string {
+	switch {
+	case d <= 5*time.Minute:
+		return "* * * * *"
+	case d <= 30*time.Minute:
+		return "H/5 * * * *"
+	case d <= time.Hour:
+		return "H/15 * * * *"
+	case d <= 8*time.Hour:
+		return "H/30 * * * *"
+	case d <= 24*time.Hour:
+		return "H H/4 * * *"
+	case d <= 48*time.Hour:
+		return "H H/12 * * *"
+	default:
+		return "H H * * *"
+	}
+}
+
+// Traits is the traits element of a branch source or navigator. The name of
+// each trait element is its class.
+type Traits struct {
+	Traits []Trait `xml:",any"`
+}
+
+// A Trait configures what a branch source or navigator discovers. Only the
+// fields of the traits managed by SCMTraits are supported.
+type Trait struct {
+	XMLName    xml.Name
+	StrategyID int    `xml:"strategyId,omitempty"`
+	Trust      *Trust `xml:"trust"`
+	Includes   string `xml:"includes,omitempty"`
+	Excludes   string `xml:"excludes,omitempty"`
+}
+
+// Trust is the trust policy of a fork pull request discovery trait.
+type Trust struct {
+	Class string `xml:"class,attr"`
+}
+
+// add adds a trait of the supplied class.
+func (t *Traits) add(class string, trait Trait) {
+	trait.XMLName = xml.Name{Local: ElementName(class)}
+	t.Traits = append(t.Traits, trait)
+}
+
+// Strategy IDs of the discovery traits.
+var (
+	branchStrategies = map[string]int{
+		string(v1alpha1.BranchDiscoveryExcludePullRequests): 1,
+		string(v1alpha1.BranchDiscoveryOnlyPullRequests):    2,
+		string(v1alpha1.BranchDiscoveryAll):                 3,
+	}
+	pullRequestStrategies = map[string]int{
+		string(v1alpha1.PullRequestDiscoveryMerge): 1,
+		string(v1alpha1.PullRequestDiscoveryHead):  2,
+		string(v1alpha1.PullRequestDiscoveryBoth):  3,
+	}
+)
+
+// An SCM is a plugin providing branch sources and navigators. Each plugin
+// provides its own discovery traits.
+type SCM struct {
+	name        string
+	branchTrait string
+	tagTrait    string
+
+	// pullRequests is the package of the pull request discovery traits.
+	// It is empty if the SCM has no pull requests.
+	pullRequests string
+	trust        map[v1alpha1.ForkPullRequestTrust]string
+}
+
+// newSCM returns an SCM whose discovery traits are in the supplied package.
+// The supplied trust policies map to classes nested in its fork pull request
+// discovery trait.
+func newSCM(name, pkg string, trust map[v1alpha1.ForkPullRequestTrust]string) SCM {
+	s := SCM{
+		name:         name,
+		branchTrait:  pkg + ".BranchDiscoveryTrait",
+		tagTrait:     pkg + ".TagDiscoveryTrait",
+		pullRequests: pkg,
+		trust:        map[v1alpha1.ForkPullRequestTrust]string{},
+	}
+	for t, class := range trust {
+		s.trust[t] = pkg + ".ForkPullRequestDiscoveryTrait$" + class
+	}
+	return s
+}
+
+// The SCMs supported by MultibranchPipelines and OrganizationFolders.
+var (
+	Git = SCM{
+		name:        "Git",
+		branchTrait: "jenkins.plugins.git.traits.BranchDiscoveryTrait",
+		tagTrait:    "jenkins.plugins.git.traits.TagDiscoveryTrait",
+	}
+	GitHub = newSCM("GitHub", "org.jenkinsci.plugins.github_branch_source", map[v1alpha1.ForkPullRequestTrust]string{
+		v1alpha1.ForkPullRequestTrustNobody:       "TrustNobody",
+		v1alpha1.ForkPullRequestTrustContributors: "TrustContributors",
+		v1alpha1.ForkPullRequestTrustEveryone:     "TrustEveryone",
+		v1alpha1.ForkPullRequestTrustPermission:   "TrustPermission",
+	})
+	Bitbucket = newSCM("Bitbucket", "com.cloudbees.jenkins.plugins.bitbucket", map[v1alpha1.ForkPullRequestTrust]string{
+		v1alpha1.ForkPullRequestTrustNobody:       "TrustNobody",
+		v1alpha1.ForkPullRequestTrustContributors: "TrustTeamForks",
+		v1alpha1.ForkPullRequestTrustEveryone:     "TrustEveryone",
+	})
+	Gitea = newSCM("Gitea", "org.jenkinsci.plugin.gitea", map[v1alpha1.ForkPullRequestTrust]string{
+		v1alpha1.ForkPullRequestTrustNobody:       "TrustNobody",
+		v1alpha1.ForkPullRequestTrustContributors: "TrustContributors",
+		v1alpha1.ForkPullRequestTrustEveryone:     "TrustEveryone",
+	})
+)
+
+// GenerateTraits produces the traits element of the supplied traits of a
+// branch source. Only branches are discovered if they are nil.
+func (s SCM) GenerateTraits(t *v1alpha1.SCMTraits) (Traits, error) {
+	if t == nil {
+		t = &v1alpha1.SCMTraits{Branches: &v1alpha1.BranchDiscovery{}}
+	}
+	traits := Traits{}
+	if t.Branches != nil {
+		b := Trait{}
+		if s.pullRequests != "" {
+			b.StrategyID = strategyID(branchStrategies, string(t.Branches.Strategy), string(v1alpha1.BranchDiscoveryExcludePullRequests))
+		}
+		traits.add(s.branchTrait, b)
+	}
+	if err := s.addPullRequestTraits(&traits, t); err != nil {
+		return Traits{}, err
+	}
+	if t.Tags {
+		traits.add(s.tagTrait, Trait{})
+	}
+	if t.HeadFilter != nil {
+		traits.add(headFilterTraitClass, wildcardTrait(t.HeadFilter))
+	}
+	return traits, nil
+}
+
+// GenerateNavigatorTraits produces the traits element of the supplied traits
+// of a navigator, which discovers the repositories matching the supplied
+// filter.
+func (s SCM) GenerateNavigatorTraits(t *v1alpha1.SCMTraits, repositories *v1alpha1.WildcardFilter) (Traits, error) {
+	traits, err := s.GenerateTraits(t)
+	if err != nil || repositories == nil {
+		return traits, err
+	}
+	filter := Traits{}
+	filter.add(sourceFilterTraitClass, wildcardTrait(repositories))
+	traits.Traits = append(filter.Traits, traits.Traits...)
+	return traits, nil
+}
+
+// addPullRequestTraits adds the pull request discovery traits of the supplied
+// traits.
+func (s SCM) addPullRequestTraits(traits *Traits, t *v1alpha1.SCMTraits) error {
+	if t.OriginPullRequests == nil && t.ForkPullRequests == nil {
+		return nil
+	}
+	if s.pullRequests == "" {
+		return errors.Errorf("%s does not support pull request discovery", s.name)
+	}
+	if t.OriginPullRequests != nil {
+		traits.add(s.pullRequests+".OriginPullRequestDiscoveryTrait", Trait{
+			StrategyID: strategyID(pullRequestStrategies, string(t.OriginPullRequests.Strategy), string(v1alpha1.PullRequestDiscoveryMerge)),
+		})
+	}
+	if t.ForkPullRequests != nil {
+		trust := t.ForkPullRequests.Trust
+		if trust == "" {
+			trust = v1alpha1.ForkPullRequestTrustContributors
+		}
+		class, ok := s.trust[trust]
+		if !ok {
+			return errors.Errorf("%s does not support the %s trust policy for fork pull requests", s.name, trust)
+		}
+		traits.add(s.pullRequests+".ForkPullRequestDiscoveryTrait", Trait{
+			StrategyID: strategyID(pullRequestStrategies, string(t.ForkPullRequests.Strategy), string(v1alpha1.PullRequestDiscoveryMerge)),
+			Trust:      &Trust{Class: class},
+		})
+	}
+	return nil
+}
+
+// strategyID returns the ID of the supplied strategy, or of the supplied
+// default strategy if it is empty.
+func strategyID(ids map[string]int, strategy, def string) int {
+	if id, ok := ids[strategy]; ok {
+		return id
+	}
+	return ids[def]
+}
+
+// wildcardTrait returns a trait filtering names by the supplied filter.
+func wildcardTrait(f *v1alpha1.WildcardFilter) Trait {
+	t := Trait{Includes: f.Includes, Excludes: f.Excludes}
+	if t.Includes == "" {
+		t.Includes = "*"
+	}
+	return t
+}
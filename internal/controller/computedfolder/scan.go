@@ -0,0 +1,88 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package computedfolder
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-jenkins/apis/dashboard/v1alpha1"
+	"github.com/crossplane/provider-jenkins/internal/clients"
+)
+
+// AnnotationKeyLastRescan is the value of the rescan annotation when a scan
+// was last scheduled for it.
+const AnnotationKeyLastRescan = "jenkins.crossplane.io/last-rescan"
+
+const (
+	errGetScan      = "cannot get last scan"
+	errScheduleScan = "cannot schedule scan"
+	errSetRescanned = "cannot record scheduled rescan"
+)
+
+// ObserveScan returns the last scan of the computed folder with the supplied
+// full name, or nil if it was never scanned.
+func ObserveScan(ctx context.Context, svc clients.Client, fullName string, computation string) (*v1alpha1.ScanObservation, error) {
+	fc, err := svc.GetFolderComputation(ctx, fullName, computation)
+	if err != nil {
+		return nil, errors.Wrap(resource.Ignore(clients.IsNotFound, err), errGetScan)
+	}
+	o := &v1alpha1.ScanObservation{Result: fc.Result}
+	if fc.Timestamp > 0 {
+		t := metav1.NewTime(time.UnixMilli(fc.Timestamp))
+		o.Timestamp = &t
+	}
+	return o, nil
+}
+
+// RescanRequested reports whether the rescan annotation of the supplied
+// object changed since a scan was last scheduled for it.
+func RescanRequested(o metav1.Object) bool {
+	v := o.GetAnnotations()[v1alpha1.AnnotationKeyRescan]
+	return v != "" && v != o.GetAnnotations()[AnnotationKeyLastRescan]
+}
+
+// SetRescanned records that a scan was scheduled for the current value of the
+// rescan annotation of the supplied object.
+func SetRescanned(o metav1.Object) {
+	if v := o.GetAnnotations()[v1alpha1.AnnotationKeyRescan]; v != "" {
+		meta.AddAnnotations(o, map[string]string{AnnotationKeyLastRescan: v})
+	}
+}
+
+// Rescan schedules a scan of the computed folder with the supplied full name
+// if one was requested through the rescan annotation of the supplied managed
+// resource. The managed reconciler does not persist annotations after Update,
+// so the annotations are persisted once the scan is scheduled.
+func Rescan(ctx context.Context, kube client.Client, svc clients.Client, mg resource.Managed, fullName string) error {
+	if !RescanRequested(mg) {
+		return nil
+	}
+	if err := svc.ScheduleFolderComputation(ctx, fullName); err != nil {
+		return errors.Wrap(err, errScheduleScan)
+	}
+	SetRescanned(mg)
+	return errors.Wrap(managed.NewRetryingCriticalAnnotationUpdater(kube).UpdateCriticalAnnotations(ctx, mg), errSetRescanned)
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-jenkins/apis/v1alpha1"
+	"github.com/crossplane/provider-jenkins/internal/clients"
+)
+
+const (
+	healthTimeout = 30 * time.Second
+
+	errGetPC         = "cannot get ProviderConfig"
+	errResolveConfig = "cannot resolve ProviderConfig"
+	errNewClient     = "cannot create Jenkins client"
+	errGetServerInfo = "cannot connect to Jenkins"
+	errUpdateStatus  = "cannot update ProviderConfig status"
+)
+
+// Event reasons.
+const (
+	reasonUnavailable event.Reason = "JenkinsUnavailable"
+	reasonAvailable   event.Reason = "JenkinsAvailable"
+)
+
+// SetupHealth adds a controller that periodically connects to the Jenkins
+// instance of each ProviderConfig and reports whether it is reachable, along
+// with its version and the authenticated user.
+func SetupHealth(mgr ctrl.Manager, o controller.Options) error {
+	name := "health/" + strings.ToLower(v1alpha1.ProviderConfigGroupKind)
+
+	r := &healthReconciler{
+		kube:         mgr.GetClient(),
+		log:          o.Logger.WithValues("controller", name),
+		record:       event.NewAPIRecorder(mgr.GetEventRecorderFor(name)),
+		newServiceFn: clients.NewClient,
+		pollInterval: o.PollInterval,
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.ProviderConfig{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A healthReconciler checks whether the Jenkins instance of a ProviderConfig
+// can be reached with the configured credentials.
+type healthReconciler struct {
+	kube         client.Client
+	log          logging.Logger
+	record       event.Recorder
+	newServiceFn func(c clients.Config) (clients.Client, error)
+	pollInterval time.Duration
+}
+
+// Reconcile a ProviderConfig by connecting to its Jenkins instance.
+func (r *healthReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithValues("request", req)
+	ctx, cancel := context.WithTimeout(ctx, healthTimeout)
+	defer cancel()
+
+	pc := &v1alpha1.ProviderConfig{}
+	if err := r.kube.Get(ctx, req.NamespacedName, pc); err != nil {
+		return reconcile.Result{}, errors.Wrap(resource.IgnoreNotFound(err), errGetPC)
+	}
+	if meta.WasDeleted(pc) {
+		return reconcile.Result{}, nil
+	}
+
+	orig := pc.DeepCopy()
+	info, err := r.check(ctx, pc)
+	if err != nil {
+		log.Debug("Jenkins is unavailable", "error", err)
+		if orig.GetCondition(xpv1.TypeReady).Reason != xpv1.ReasonUnavailable {
+			r.record.Event(pc, event.Warning(reasonUnavailable, err))
+		}
+		pc.SetConditions(xpv1.Unavailable().WithMessage(err.Error()))
+	} else {
+		if orig.GetCondition(xpv1.TypeReady).Reason != xpv1.ReasonAvailable {
+			r.record.Event(pc, event.Normal(reasonAvailable, "Successfully connected to Jenkins", "version", info.Version, "user", info.User))
+		}
+		pc.Status.JenkinsVersion = info.Version
+		pc.Status.AuthenticatedUser = info.User
+		pc.SetConditions(xpv1.Available())
+	}
+
+	return reconcile.Result{RequeueAfter: r.pollInterval}, errors.Wrap(r.kube.Status().Patch(ctx, pc, client.MergeFrom(orig)), errUpdateStatus)
+}
+
+func (r *healthReconciler) check(ctx context.Context, pc *v1alpha1.ProviderConfig) (*clients.ServerInfo, error) {
+	cfg, err := clients.ResolveConfig(ctx, r.kube, pc)
+	if err != nil {
+		return nil, errors.Wrap(err, errResolveConfig)
+	}
+	svc, err := r.newServiceFn(*cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	info, err := svc.GetServerInfo(ctx)
+	return info, errors.Wrap(err, errGetServerInfo)
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credential
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane/provider-jenkins/apis/dashboard/v1alpha1"
+	"github.com/crossplane/provider-jenkins/internal/clients"
+)
+
+// Classes of the supported credential types.
+const (
+	usernamePasswordClass = "com.cloudbees.plugins.credentials.impl.UsernamePasswordCredentialsImpl"
+	secretTextClass       = "org.jenkinsci.plugins.plaincredentials.impl.StringCredentialsImpl"
+	sshPrivateKeyClass    = "com.cloudbees.jenkins.plugins.sshcredentials.impl.BasicSSHUserPrivateKey"
+	certificateClass      = "com.cloudbees.plugins.credentials.impl.CertificateCredentialsImpl"
+	fileClass             = "org.jenkinsci.plugins.plaincredentials.impl.FileCredentialsImpl"
+
+	directEntryPrivateKeySourceClass = sshPrivateKeyClass + "$DirectEntryPrivateKeySource"
+	uploadedKeyStoreSourceClass      = certificateClass + "$UploadedKeyStoreSource"
+)
+
+const (
+	errCredentialType = "exactly one of usernamePassword, secretText, sshPrivateKey, certificate and file must be set"
+	errGetSecretFmt   = "cannot get secret %s/%s"
+	errSecretKeyFmt   = "secret %s/%s has no key %s"
+)
+
+// credentialConfig is the config.xml of a credential. Only the elements of
+// the credential's type are set.
+type credentialConfig struct {
+	XMLName          xml.Name
+	Scope            string            `xml:"scope"`
+	ID               string            `xml:"id"`
+	Description      string            `xml:"description"`
+	Username         string            `xml:"username,omitempty"`
+	Password         string            `xml:"password,omitempty"`
+	Secret           string            `xml:"secret,omitempty"`
+	Passphrase       string            `xml:"passphrase,omitempty"`
+	PrivateKeySource *privateKeySource `xml:"privateKeySource,omitempty"`
+	KeyStoreSource   *keyStoreSource   `xml:"keyStoreSource,omitempty"`
+	FileName         string            `xml:"fileName,omitempty"`
+	SecretBytes      string            `xml:"secretBytes,omitempty"`
+}
+
+type privateKeySource struct {
+	Class      string `xml:"class,attr"`
+	PrivateKey string `xml:"privateKey"`
+}
+
+type keyStoreSource struct {
+	Class                 string `xml:"class,attr"`
+	UploadedKeystoreBytes string `xml:"uploadedKeystoreBytes"`
+}
+
+// sameAs reports whether the non-secret fields of the supplied config match.
+// Jenkins encrypts secret material, so it cannot be compared.
+func (c credentialConfig) sameAs(o credentialConfig) bool {
+	return c.XMLName.Local == o.XMLName.Local &&
+		c.Scope == o.Scope &&
+		c.ID == o.ID &&
+		c.Description == o.Description &&
+		c.Username == o.Username &&
+		c.FileName == o.FileName
+}
+
+// A secretReader reads keys of Kubernetes Secrets and records the versions of
+// the Secrets it read.
+type secretReader struct {
+	kube     client.Client
+	versions []string
+}
+
+func (r *secretReader) read(ctx context.Context, ref xpv1.SecretKeySelector) ([]byte, error) {
+	s := &corev1.Secret{}
+	if err := r.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return nil, errors.Wrapf(err, errGetSecretFmt, ref.Namespace, ref.Name)
+	}
+	v, ok := s.Data[ref.Key]
+	if !ok {
+		return nil, errors.Errorf(errSecretKeyFmt, ref.Namespace, ref.Name, ref.Key)
+	}
+	r.versions = append(r.versions, ref.Namespace+"/"+ref.Name+"/"+ref.Key+"@"+s.GetResourceVersion())
+	return v, nil
+}
+
+// version identifies the versions of the Secrets that were read. It changes
+// whenever one of them is updated, but reveals nothing about their content.
+func (r *secretReader) version() string {
+	sum := sha256.Sum256([]byte(strings.Join(r.versions, ",")))
+	return hex.EncodeToString(sum[:])
+}
+
+// generateConfig produces the config.xml of the supplied credential along
+// with the version of the Secrets its secret material was read from.
+func generateConfig(ctx context.Context, kube client.Client, p v1alpha1.CredentialParameters) (*credentialConfig, string, error) { //nolint:gocyclo // One branch per credential type.
+	r := &secretReader{kube: kube}
+	scope := v1alpha1.CredentialScopeGlobal
+	if p.Scope != "" {
+		scope = p.Scope
+	}
+	cfg := &credentialConfig{Scope: strings.ToUpper(string(scope)), ID: p.ID, Description: p.Description}
+
+	set := 0
+	if u := p.UsernamePassword; u != nil {
+		set++
+		pw, err := r.read(ctx, u.PasswordSecretRef)
+		if err != nil {
+			return nil, "", err
+		}
+		cfg.XMLName.Local = usernamePasswordClass
+		cfg.Username, cfg.Password = u.Username, string(pw)
+	}
+	if t := p.SecretText; t != nil {
+		set++
+		s, err := r.read(ctx, t.SecretRef)
+		if err != nil {
+			return nil, "", err
+		}
+		cfg.XMLName.Local = secretTextClass
+		cfg.Secret = string(s)
+	}
+	if k := p.SSHPrivateKey; k != nil {
+		set++
+		key, err := r.read(ctx, k.PrivateKeySecretRef)
+		if err != nil {
+			return nil, "", err
+		}
+		cfg.XMLName.Local = sshPrivateKeyClass
+		cfg.Username = k.Username
+		cfg.PrivateKeySource = &privateKeySource{Class: directEntryPrivateKeySourceClass, PrivateKey: string(key)}
+		if k.PassphraseSecretRef != nil {
+			pp, err := r.read(ctx, *k.PassphraseSecretRef)
+			if err != nil {
+				return nil, "", err
+			}
+			cfg.Passphrase = string(pp)
+		}
+	}
+	if c := p.Certificate; c != nil {
+		set++
+		ks, err := r.read(ctx, c.KeyStoreSecretRef)
+		if err != nil {
+			return nil, "", err
+		}
+		cfg.XMLName.Local = certificateClass
+		cfg.KeyStoreSource = &keyStoreSource{Class: uploadedKeyStoreSourceClass, UploadedKeystoreBytes: base64.StdEncoding.EncodeToString(ks)}
+		if c.PasswordSecretRef != nil {
+			pw, err := r.read(ctx, *c.PasswordSecretRef)
+			if err != nil {
+				return nil, "", err
+			}
+			cfg.Password = string(pw)
+		}
+	}
+	if f := p.File; f != nil {
+		set++
+		b, err := r.read(ctx, f.SecretRef)
+		if err != nil {
+			return nil, "", err
+		}
+		cfg.XMLName.Local = fileClass
+		cfg.FileName = f.FileName
+		cfg.SecretBytes = base64.StdEncoding.EncodeToString(b)
+	}
+	if set != 1 {
+		return nil, "", errors.New(errCredentialType)
+	}
+	return cfg, r.version(), nil
+}
+
+// encodeConfig encodes the supplied config.xml.
+func encodeConfig(cfg *credentialConfig) (string, error) {
+	b, err := xml.MarshalIndent(cfg, "", "  ")
+	return string(b), errors.Wrap(err, "cannot encode config.xml")
+}
+
+// decodeConfig decodes the supplied config.xml.
+func decodeConfig(doc string) (credentialConfig, error) {
+	cfg := credentialConfig{}
+	return cfg, clients.DecodeXML(doc, &cfg)
+}
@@ -0,0 +1,296 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credential
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-jenkins/apis/dashboard/v1alpha1"
+	apisv1alpha1 "github.com/crossplane/provider-jenkins/apis/v1alpha1"
+	"github.com/crossplane/provider-jenkins/internal/clients"
+	"github.com/crossplane/provider-jenkins/internal/controller/features"
+	"github.com/crossplane/provider-jenkins/internal/controller/item"
+)
+
+// annotationKeySecretVersion identifies the versions of the Secrets whose
+// content was last written to Jenkins. Jenkins encrypts secret material, so
+// this is how changes to the Secrets are detected.
+const annotationKeySecretVersion = "jenkins.crossplane.io/secret-version"
+
+// Annotations identifying the credential domain the credential was last
+// written to. The external name is only the ID of the credential, so they are
+// how the credential is found after its folder or domain changes.
+const (
+	annotationKeyFolder = "jenkins.crossplane.io/credential-folder"
+	annotationKeyDomain = "jenkins.crossplane.io/credential-domain"
+)
+
+const (
+	errNotCredential       = "managed resource is not a Credential custom resource"
+	errNewClient           = "cannot create Jenkins client"
+	errTrackPCUsage        = "cannot track ProviderConfig usage"
+	errGenerateConfig      = "cannot generate Jenkins credential config"
+	errGetCredential       = "cannot get Jenkins credential"
+	errGetCredentialConfig = "cannot get Jenkins credential config"
+	errCreateCredential    = "cannot create Jenkins credential"
+	errUpdateCredential    = "cannot update Jenkins credential"
+	errDeleteCredential    = "cannot delete Jenkins credential"
+)
+
+// Setup adds a controller that reconciles Credential managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.CredentialGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	cc := clients.NewClientCache(clients.NewClient)
+	if err := cc.EvictOnDelete(context.Background(), mgr.GetCache()); err != nil {
+		return err
+	}
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.CredentialGroupVersionKind),
+		managed.WithExternalConnecter(&connector{
+			kube:    mgr.GetClient(),
+			usage:   resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			clients: cc}),
+		// The external name is the ID of the credential, which is set by
+		// Create rather than defaulted to the name of the managed resource.
+		managed.WithInitializers(),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.Credential{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube    client.Client
+	usage   resource.Tracker
+	clients *clients.ClientCache
+}
+
+// Connect produces an ExternalClient by tracking that the managed resource is
+// using a ProviderConfig and getting a client for that ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.Credential)
+	if !ok {
+		return nil, errors.New(errNotCredential)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	svc, err := c.clients.Get(ctx, c.kube, cr)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &external{kube: c.kube, service: svc}, nil
+}
+
+// An external observes, then either creates, updates, or deletes a Jenkins
+// credential to ensure it reflects the managed resource's desired state.
+type external struct {
+	kube    client.Client
+	service clients.Client
+}
+
+// domain returns the credential domain containing the credential.
+func domain(p v1alpha1.CredentialParameters) clients.CredentialDomain {
+	return clients.CredentialDomain{Folder: p.Folder, Domain: p.Domain}
+}
+
+// recordedDomain returns the credential domain the credential was last written
+// to. Credentials imported by setting their external name are expected in the
+// desired domain.
+func recordedDomain(cr *v1alpha1.Credential) clients.CredentialDomain {
+	a := cr.GetAnnotations()
+	folder, ok := a[annotationKeyFolder]
+	if !ok {
+		return domain(cr.Spec.ForProvider)
+	}
+	return clients.CredentialDomain{Folder: folder, Domain: a[annotationKeyDomain]}
+}
+
+// record records the ID and credential domain the credential was written to,
+// along with the version of the Secrets its secret material was read from.
+func record(cr *v1alpha1.Credential, version string) {
+	p := cr.Spec.ForProvider
+	meta.SetExternalName(cr, p.ID)
+	meta.AddAnnotations(cr, map[string]string{
+		annotationKeySecretVersion: version,
+		annotationKeyFolder:        p.Folder,
+		annotationKeyDomain:        p.Domain,
+	})
+}
+
+// isIdentified reports whether the observed credential has the desired ID and
+// is in the desired credential domain.
+func isIdentified(cr *v1alpha1.Credential) bool {
+	p := cr.Spec.ForProvider
+	return meta.GetExternalName(cr) == p.ID && recordedDomain(cr) == domain(p)
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Credential)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotCredential)
+	}
+
+	// The external name is the ID of the credential in Jenkins. It is set by
+	// Create, or by the user to import an existing credential.
+	externalName := meta.GetExternalName(cr)
+	if externalName == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	p := cr.Spec.ForProvider
+	cred, err := c.service.GetCredential(ctx, recordedDomain(cr), externalName)
+	if err != nil {
+		if clients.IsNotFound(err) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetCredential)
+	}
+	cr.Status.AtProvider = v1alpha1.CredentialObservation{
+		FullName:    cred.FullName,
+		DisplayName: cred.DisplayName,
+		TypeName:    cred.TypeName,
+	}
+	cr.SetConditions(xpv1.Available())
+
+	desired, version, err := generateConfig(ctx, c.kube, p)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGenerateConfig)
+	}
+	if !isIdentified(cr) || cr.GetAnnotations()[annotationKeySecretVersion] != version {
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}, nil
+	}
+
+	doc, err := c.service.GetCredentialConfig(ctx, recordedDomain(cr), externalName)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetCredentialConfig)
+	}
+	observed, err := decodeConfig(doc)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetCredentialConfig)
+	}
+
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: desired.sameAs(observed)}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Credential)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotCredential)
+	}
+
+	p := cr.Spec.ForProvider
+	cfg, version, err := generateConfig(ctx, c.kube, p)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errGenerateConfig)
+	}
+	doc, err := encodeConfig(cfg)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errGenerateConfig)
+	}
+	if err := c.service.CreateCredential(ctx, domain(p), doc); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateCredential)
+	}
+
+	// The managed reconciler persists annotations after Create.
+	record(cr, version)
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Credential)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotCredential)
+	}
+
+	p := cr.Spec.ForProvider
+	cfg, version, err := generateConfig(ctx, c.kube, p)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGenerateConfig)
+	}
+	doc, err := encodeConfig(cfg)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGenerateConfig)
+	}
+	from, to := recordedDomain(cr), domain(p)
+	if from == to {
+		// Updating the credential identified by the external name also
+		// changes its ID if the ID of the Credential changed.
+		if err := c.service.UpdateCredentialConfig(ctx, to, meta.GetExternalName(cr), doc); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateCredential)
+		}
+	} else {
+		// Jenkins cannot move a credential to another credential domain, so
+		// it is deleted and created again. If creating it fails, the next
+		// Observe finds no credential and Create retries in the new domain.
+		err := c.service.DeleteCredential(ctx, from, meta.GetExternalName(cr))
+		if err := resource.Ignore(clients.IsNotFound, err); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errDeleteCredential)
+		}
+		if err := c.service.CreateCredential(ctx, to, doc); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errCreateCredential)
+		}
+	}
+
+	record(cr, version)
+	return managed.ExternalUpdate{}, item.UpdateAnnotations(ctx, c.kube, cr)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Credential)
+	if !ok {
+		return errors.New(errNotCredential)
+	}
+
+	// A Credential without an external name was never created in Jenkins.
+	externalName := meta.GetExternalName(cr)
+	if externalName == "" {
+		return nil
+	}
+
+	err := c.service.DeleteCredential(ctx, recordedDomain(cr), externalName)
+	return errors.Wrap(resource.Ignore(clients.IsNotFound, err), errDeleteCredential)
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credential
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-jenkins/apis/dashboard/v1alpha1"
+	"github.com/crossplane/provider-jenkins/internal/clients"
+	"github.com/crossplane/provider-jenkins/internal/clients/fake"
+)
+
+type credentialModifier func(*v1alpha1.Credential)
+
+func withFolder(f string) credentialModifier {
+	return func(cr *v1alpha1.Credential) { cr.Spec.ForProvider.Folder = f }
+}
+
+func withDomain(d string) credentialModifier {
+	return func(cr *v1alpha1.Credential) { cr.Spec.ForProvider.Domain = d }
+}
+
+func credential(m ...credentialModifier) *v1alpha1.Credential {
+	cr := &v1alpha1.Credential{Spec: v1alpha1.CredentialSpec{ForProvider: v1alpha1.CredentialParameters{
+		ID: "token",
+		SecretText: &v1alpha1.SecretTextCredential{
+			SecretRef: xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Namespace: "default", Name: "token"}, Key: "token"},
+		},
+	}}}
+	cr.SetName("token")
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+// kube returns a Kubernetes client that serves the Secret of the credential
+// returned by credential.
+func kube() client.Client {
+	return &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			if s, ok := obj.(*corev1.Secret); ok {
+				s.SetResourceVersion("1")
+				s.Data = map[string][]byte{"token": []byte("s3cr3t")}
+			}
+			return nil
+		}),
+		MockUpdate: test.NewMockUpdateFn(nil),
+	}
+}
+
+// newExternal returns an external client connected to a new fake Jenkins
+// server with a folder named team.
+func newExternal(t *testing.T) (*external, *fake.Server) {
+	t.Helper()
+	s := fake.NewServer()
+	t.Cleanup(s.Close)
+	s.AddFolder("team")
+	svc, err := clients.NewClient(s.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &external{kube: kube(), service: svc}, s
+}
+
+// created returns the Credential after it was created by the supplied external
+// client, with the supplied modifiers applied afterwards.
+func created(t *testing.T, e *external, m ...credentialModifier) *v1alpha1.Credential {
+	t.Helper()
+	cr := credential()
+	if _, err := e.Create(context.Background(), cr); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		mg     func(t *testing.T, e *external) resource.Managed
+		want   want
+	}{
+		"NotCredential": {
+			reason: "We should return an error if the managed resource is not a Credential.",
+			mg:     func(*testing.T, *external) resource.Managed { return nil },
+			want:   want{err: errors.New(errNotCredential)},
+		},
+		"NotCreated": {
+			reason: "A Credential without an external name should be created.",
+			mg:     func(*testing.T, *external) resource.Managed { return credential() },
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"UpToDate": {
+			reason: "A Credential whose credential matches should be up to date.",
+			mg:     func(t *testing.T, e *external) resource.Managed { return created(t, e) },
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"FolderChanged": {
+			reason: "A Credential whose folder changed should find its credential in the store it was created in, and update it.",
+			mg:     func(t *testing.T, e *external) resource.Managed { return created(t, e, withFolder("team")) },
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}},
+		},
+		"DomainChanged": {
+			reason: "A Credential whose domain changed should find its credential in the domain it was created in, and update it.",
+			mg:     func(t *testing.T, e *external) resource.Managed { return created(t, e, withDomain("deploy")) },
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}},
+		},
+		"Imported": {
+			reason: "A Credential imported by its external name should find its credential in the desired domain.",
+			mg: func(t *testing.T, e *external) resource.Managed {
+				cr := credential(withFolder("team"))
+				cfg, _, err := generateConfig(context.Background(), e.kube, cr.Spec.ForProvider)
+				if err != nil {
+					t.Fatal(err)
+				}
+				doc, _ := encodeConfig(cfg)
+				if err := e.service.CreateCredential(context.Background(), domain(cr.Spec.ForProvider), doc); err != nil {
+					t.Fatal(err)
+				}
+				meta.SetExternalName(cr, "token")
+				return cr
+			},
+			want: want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e, _ := newExternal(t)
+			got, err := e.Observe(context.Background(), tc.mg(t, e))
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type want struct {
+		domain clients.CredentialDomain
+		gone   *clients.CredentialDomain
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		m      []credentialModifier
+		want   want
+	}{
+		"Updated": {
+			reason: "A Credential whose domain did not change should be updated in place.",
+			want:   want{domain: clients.CredentialDomain{}},
+		},
+		"MovedToFolder": {
+			reason: "A Credential whose folder changed should be moved to the store of the new folder.",
+			m:      []credentialModifier{withFolder("team")},
+			want: want{
+				domain: clients.CredentialDomain{Folder: "team"},
+				gone:   &clients.CredentialDomain{},
+			},
+		},
+		"MovedToDomain": {
+			reason: "A Credential whose domain changed should be moved to the new domain.",
+			m:      []credentialModifier{withDomain("deploy")},
+			want: want{
+				domain: clients.CredentialDomain{Domain: "deploy"},
+				gone:   &clients.CredentialDomain{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e, s := newExternal(t)
+			cr := created(t, e, tc.m...)
+			_, err := e.Update(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.domain, recordedDomain(cr)); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want recorded domain, +got recorded domain:\n%s", tc.reason, diff)
+			}
+			if _, ok := s.GetCredential(tc.want.domain.Folder, tc.want.domain.Domain, "token"); !ok {
+				t.Errorf("\n%s\ne.Update(...): credential does not exist in %+v", tc.reason, tc.want.domain)
+			}
+			if d := tc.want.gone; d != nil {
+				if _, ok := s.GetCredential(d.Folder, d.Domain, "token"); ok {
+					t.Errorf("\n%s\ne.Update(...): credential still exists in %+v", tc.reason, *d)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package folder
+
+import (
+	"encoding/xml"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/provider-jenkins/apis/dashboard/v1alpha1"
+	"github.com/crossplane/provider-jenkins/internal/clients"
+)
+
+// folderConfig is the part of a folder's config.xml managed by a Folder.
+// Elements that are not managed, e.g. views, are defaulted by Jenkins.
+type folderConfig struct {
+	XMLName       xml.Name      `xml:"com.cloudbees.hudson.plugins.folder.Folder"`
+	DisplayName   string        `xml:"displayName,omitempty"`
+	Description   string        `xml:"description"`
+	Properties    properties    `xml:"properties"`
+	HealthMetrics healthMetrics `xml:"healthMetrics"`
+}
+
+// properties are the properties of a folder. Each property is an element
+// named after its class, e.g. the folder scoped credentials of Credential
+// managed resources.
+type properties struct {
+	Properties []property `xml:",any"`
+}
+
+// A property is kept verbatim.
+type property struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Inner   string     `xml:",innerxml"`
+}
+
+// get returns the property with the supplied name, or nil.
+func (p properties) get(name xml.Name) *property {
+	for i := range p.Properties {
+		if p.Properties[i].XMLName == name {
+			return &p.Properties[i]
+		}
+	}
+	return nil
+}
+
+// set replaces the property with the name of the supplied property, or adds
+// it if there is none.
+func (p *properties) set(prop property) {
+	if o := p.get(prop.XMLName); o != nil {
+		*o = prop
+		return
+	}
+	p.Properties = append(p.Properties, prop)
+}
+
+// decodeProperties decodes the supplied XML content of a properties element.
+func decodeProperties(inner string) (properties, error) {
+	props := properties{}
+	err := clients.DecodeXML("<properties>"+inner+"</properties>", &props)
+	return props, errors.Wrap(err, "cannot decode properties")
+}
+
+// encode returns the supplied property as a document for comparison.
+func (p property) encode() string {
+	b, _ := xml.Marshal(p)
+	return string(b)
+}
+
+type healthMetrics struct {
+	WorstChildHealth []worstChildHealthMetric `xml:"com.cloudbees.hudson.plugins.folder.health.WorstChildHealthMetric"`
+}
+
+type worstChildHealthMetric struct {
+	NonRecursive bool `xml:"nonRecursive"`
+}
+
+// generateConfig produces the config.xml of the supplied folder parameters.
+// The properties of the supplied observed config.xml, if any, are preserved
+// unless the parameters declare a property of the same class. Properties are
+// also added by other managed resources, e.g. folder scoped credentials.
+func generateConfig(p v1alpha1.FolderParameters, observed string) (string, error) {
+	cfg := folderConfig{
+		DisplayName: p.DisplayName,
+		Description: p.Description,
+	}
+	if observed != "" {
+		o := folderConfig{}
+		if err := clients.DecodeXML(observed, &o); err != nil {
+			return "", err
+		}
+		cfg.Properties = o.Properties
+	}
+	declared, err := decodeProperties(p.Properties)
+	if err != nil {
+		return "", err
+	}
+	for _, prop := range declared.Properties {
+		cfg.Properties.set(prop)
+	}
+	for _, m := range p.HealthMetrics {
+		if m.Type == v1alpha1.FolderHealthMetricWorstChildHealth {
+			cfg.HealthMetrics.WorstChildHealth = append(cfg.HealthMetrics.WorstChildHealth, worstChildHealthMetric{NonRecursive: m.Recursive != nil && !*m.Recursive})
+		}
+	}
+	b, err := xml.MarshalIndent(cfg, "", "  ")
+	return string(b), errors.Wrap(err, "cannot encode config.xml")
+}
+
+// isUpToDate reports whether the supplied config.xml of a folder matches the
+// supplied desired config.xml. Only the properties of the desired config.xml
+// are compared.
+func isUpToDate(desired, observed string) (bool, error) {
+	d, o := folderConfig{}, folderConfig{}
+	if err := clients.DecodeXML(desired, &d); err != nil {
+		return false, err
+	}
+	if err := clients.DecodeXML(observed, &o); err != nil {
+		return false, err
+	}
+	if d.DisplayName != o.DisplayName || d.Description != o.Description {
+		return false, nil
+	}
+	for _, prop := range d.Properties.Properties {
+		observed := o.Properties.get(prop.XMLName)
+		if observed == nil || !clients.EqualXML(prop.encode(), observed.encode(), true) {
+			return false, nil
+		}
+	}
+	if len(d.HealthMetrics.WorstChildHealth) != len(o.HealthMetrics.WorstChildHealth) {
+		return false, nil
+	}
+	for i := range d.HealthMetrics.WorstChildHealth {
+		if d.HealthMetrics.WorstChildHealth[i] != o.HealthMetrics.WorstChildHealth[i] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
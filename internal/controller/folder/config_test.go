@@ -0,0 +1,189 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package folder
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-jenkins/apis/dashboard/v1alpha1"
+	"github.com/crossplane/provider-jenkins/internal/clients"
+)
+
+const (
+	credentialsProperty = `<com.cloudbees.hudson.plugins.folder.properties.FolderCredentialsProvider_-FolderCredentialsProperty plugin="cloudbees-folder@6.15"><domainCredentialsMap class="hudson.util.CopyOnWriteMap$Hash"></domainCredentialsMap></com.cloudbees.hudson.plugins.folder.properties.FolderCredentialsProvider_-FolderCredentialsProperty>`
+	envProperty         = `<com.cloudbees.hudson.plugins.folder.properties.EnvVarsFolderProperty><properties>A=1</properties></com.cloudbees.hudson.plugins.folder.properties.EnvVarsFolderProperty>`
+	envPropertyChanged  = `<com.cloudbees.hudson.plugins.folder.properties.EnvVarsFolderProperty><properties>A=2</properties></com.cloudbees.hudson.plugins.folder.properties.EnvVarsFolderProperty>`
+)
+
+func observedConfig(props string) string {
+	return `<?xml version='1.1' encoding='UTF-8'?>
+<com.cloudbees.hudson.plugins.folder.Folder plugin="cloudbees-folder@6.15">
+  <description>folder</description>
+  <properties>` + props + `</properties>
+  <views/>
+  <healthMetrics/>
+</com.cloudbees.hudson.plugins.folder.Folder>`
+}
+
+func TestGenerateConfig(t *testing.T) {
+	type args struct {
+		p        v1alpha1.FolderParameters
+		observed string
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   string
+	}{
+		"Create": {
+			reason: "The declared properties should be written when there is no observed config.xml.",
+			args: args{
+				p: v1alpha1.FolderParameters{Description: "folder", Properties: envProperty},
+			},
+			want: observedConfig(envProperty),
+		},
+		"PreserveUndeclared": {
+			reason: "Properties that are not declared, e.g. folder scoped credentials, should be preserved.",
+			args: args{
+				p:        v1alpha1.FolderParameters{Description: "folder", Properties: envProperty},
+				observed: observedConfig(credentialsProperty),
+			},
+			want: observedConfig(credentialsProperty + envProperty),
+		},
+		"ReplaceDeclared": {
+			reason: "Declared properties should replace the observed property of the same class.",
+			args: args{
+				p:        v1alpha1.FolderParameters{Description: "folder", Properties: envPropertyChanged},
+				observed: observedConfig(envProperty + credentialsProperty),
+			},
+			want: observedConfig(envPropertyChanged + credentialsProperty),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			config, err := generateConfig(tc.args.p, tc.args.observed)
+			if err != nil {
+				t.Fatalf("\n%s\ngenerateConfig(...): unexpected error: %v", tc.reason, err)
+			}
+			got, err := isUpToDate(config, tc.want)
+			if err != nil {
+				t.Fatalf("\n%s\nisUpToDate(...): unexpected error: %v", tc.reason, err)
+			}
+			if !got {
+				t.Errorf("\n%s\ngenerateConfig(...): got config.xml:\n%s", tc.reason, config)
+			}
+			if diff := cmp.Diff(propertyNames(t, tc.want), propertyNames(t, config)); diff != "" {
+				t.Errorf("\n%s\ngenerateConfig(...): -want properties, +got properties:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	type args struct {
+		p        v1alpha1.FolderParameters
+		observed string
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   bool
+	}{
+		"UpToDate": {
+			reason: "A folder whose declared properties match should be up to date.",
+			args: args{
+				p:        v1alpha1.FolderParameters{Description: "folder", Properties: envProperty},
+				observed: observedConfig(envProperty),
+			},
+			want: true,
+		},
+		"UndeclaredProperty": {
+			reason: "Properties that are not declared should not make a folder out of date.",
+			args: args{
+				p:        v1alpha1.FolderParameters{Description: "folder", Properties: envProperty},
+				observed: observedConfig(credentialsProperty + envProperty),
+			},
+			want: true,
+		},
+		"NoDeclaredProperties": {
+			reason: "A folder without declared properties should ignore the observed properties.",
+			args: args{
+				p:        v1alpha1.FolderParameters{Description: "folder"},
+				observed: observedConfig(credentialsProperty),
+			},
+			want: true,
+		},
+		"MissingProperty": {
+			reason: "A folder that lacks a declared property should be out of date.",
+			args: args{
+				p:        v1alpha1.FolderParameters{Description: "folder", Properties: envProperty},
+				observed: observedConfig(credentialsProperty),
+			},
+			want: false,
+		},
+		"ChangedProperty": {
+			reason: "A folder whose declared property differs should be out of date.",
+			args: args{
+				p:        v1alpha1.FolderParameters{Description: "folder", Properties: envPropertyChanged},
+				observed: observedConfig(envProperty),
+			},
+			want: false,
+		},
+		"ChangedDescription": {
+			reason: "A folder whose description differs should be out of date.",
+			args: args{
+				p:        v1alpha1.FolderParameters{Description: "changed"},
+				observed: observedConfig(""),
+			},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			desired, err := generateConfig(tc.args.p, "")
+			if err != nil {
+				t.Fatalf("\n%s\ngenerateConfig(...): unexpected error: %v", tc.reason, err)
+			}
+			got, err := isUpToDate(desired, tc.args.observed)
+			if err != nil {
+				t.Fatalf("\n%s\nisUpToDate(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nisUpToDate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func propertyNames(t *testing.T, config string) []string {
+	t.Helper()
+	cfg := folderConfig{}
+	if err := clients.DecodeXML(config, &cfg); err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, 0, len(cfg.Properties.Properties))
+	for _, p := range cfg.Properties.Properties {
+		names = append(names, p.XMLName.Local)
+	}
+	return names
+}
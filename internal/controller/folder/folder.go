@@ -0,0 +1,239 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package folder
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-jenkins/apis/dashboard/v1alpha1"
+	apisv1alpha1 "github.com/crossplane/provider-jenkins/apis/v1alpha1"
+	"github.com/crossplane/provider-jenkins/internal/clients"
+	"github.com/crossplane/provider-jenkins/internal/controller/features"
+	"github.com/crossplane/provider-jenkins/internal/controller/item"
+)
+
+const (
+	errNotFolder       = "managed resource is not a Folder custom resource"
+	errNewClient       = "cannot create Jenkins client"
+	errTrackPCUsage    = "cannot track ProviderConfig usage"
+	errGenerateConfig  = "cannot generate Jenkins folder config"
+	errGetFolder       = "cannot get Jenkins folder"
+	errGetFolderConfig = "cannot get Jenkins folder config"
+	errCompareConfig   = "cannot compare Jenkins folder config"
+	errCreateFolder    = "cannot create Jenkins folder"
+	errUpdateFolder    = "cannot update Jenkins folder config"
+	errDeleteFolder    = "cannot delete Jenkins folder"
+)
+
+// Setup adds a controller that reconciles Folder managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.FolderGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	cc := clients.NewClientCache(clients.NewClient)
+	if err := cc.EvictOnDelete(context.Background(), mgr.GetCache()); err != nil {
+		return err
+	}
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.FolderGroupVersionKind),
+		managed.WithExternalConnecter(&connector{
+			kube:    mgr.GetClient(),
+			usage:   resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			clients: cc}),
+		// The external name is the full name of the folder, which is set by
+		// Create rather than defaulted to the name of the managed resource.
+		managed.WithInitializers(),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.Folder{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube    client.Client
+	usage   resource.Tracker
+	clients *clients.ClientCache
+}
+
+// Connect produces an ExternalClient by tracking that the managed resource is
+// using a ProviderConfig and getting a client for that ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.Folder)
+	if !ok {
+		return nil, errors.New(errNotFolder)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	svc, err := c.clients.Get(ctx, c.kube, cr)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &external{kube: c.kube, service: svc}, nil
+}
+
+// An external observes, then either creates, updates, or deletes a Jenkins
+// folder to ensure it reflects the managed resource's desired state.
+type external struct {
+	kube    client.Client
+	service clients.Client
+}
+
+// fullName returns the full name of the folder, e.g. "team/sub/folder".
+func fullName(p v1alpha1.FolderParameters) string {
+	return item.FullName(p.Parent, p.Name)
+}
+
+// generateObservation produces a FolderObservation from the state of a folder
+// as reported by Jenkins.
+func generateObservation(f *clients.Folder) v1alpha1.FolderObservation {
+	o := v1alpha1.FolderObservation{FullName: f.FullName, URL: f.URL}
+	for i := range f.HealthReport {
+		if o.HealthScore == nil || f.HealthReport[i].Score < *o.HealthScore {
+			o.HealthScore = &f.HealthReport[i].Score
+		}
+	}
+	for _, j := range f.Jobs {
+		o.Items = append(o.Items, j.Name)
+	}
+	return o
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Folder)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotFolder)
+	}
+
+	// The external name is the full name of the folder in Jenkins. It is set
+	// by Create, or by the user to import an existing folder.
+	externalName := meta.GetExternalName(cr)
+	if externalName == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	f, err := c.service.GetFolder(ctx, externalName)
+	if err != nil {
+		if clients.IsNotFound(err) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetFolder)
+	}
+	cr.Status.AtProvider = generateObservation(f)
+	cr.SetConditions(xpv1.Available())
+
+	if externalName != fullName(cr.Spec.ForProvider) {
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}, nil
+	}
+
+	desired, err := generateConfig(cr.Spec.ForProvider, "")
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGenerateConfig)
+	}
+	observed, err := c.service.GetFolderConfig(ctx, externalName)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetFolderConfig)
+	}
+	upToDate, err := isUpToDate(desired, observed)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errCompareConfig)
+	}
+
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: upToDate}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Folder)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotFolder)
+	}
+
+	config, err := generateConfig(cr.Spec.ForProvider, "")
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errGenerateConfig)
+	}
+	if err := c.service.CreateFolder(ctx, fullName(cr.Spec.ForProvider), config); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFolder)
+	}
+	meta.SetExternalName(cr, fullName(cr.Spec.ForProvider))
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Folder)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotFolder)
+	}
+
+	if err := item.Move(ctx, c.kube, c.service, cr, cr.Spec.ForProvider.Parent, cr.Spec.ForProvider.Name); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	observed, err := c.service.GetFolderConfig(ctx, fullName(cr.Spec.ForProvider))
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetFolderConfig)
+	}
+	config, err := generateConfig(cr.Spec.ForProvider, observed)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGenerateConfig)
+	}
+	err = c.service.UpdateFolderConfig(ctx, fullName(cr.Spec.ForProvider), config)
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFolder)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Folder)
+	if !ok {
+		return errors.New(errNotFolder)
+	}
+
+	// A Folder without an external name was never created in Jenkins.
+	externalName := meta.GetExternalName(cr)
+	if externalName == "" {
+		return nil
+	}
+
+	err := c.service.DeleteFolder(ctx, externalName)
+	return errors.Wrap(resource.Ignore(clients.IsNotFound, err), errDeleteFolder)
+}
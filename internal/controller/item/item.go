@@ -0,0 +1,83 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package item contains logic shared by the controllers of Jenkins items, i.e.
+// jobs and the various kinds of folders.
+package item
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-jenkins/internal/clients"
+)
+
+const (
+	errMove              = "cannot move Jenkins item"
+	errRename            = "cannot rename Jenkins item"
+	errUpdateAnnotations = "cannot update annotations"
+)
+
+// FullName returns the full name of the item with the supplied parent and
+// name, e.g. "folder/sub/job".
+func FullName(parent, name string) string {
+	return strings.Trim(path.Join(parent, name), "/")
+}
+
+// Move moves and renames the item identified by the external name of the
+// supplied managed resource to match the supplied parent and name. The
+// external name is persisted after each step so that the item is not orphaned
+// if a later step fails.
+func Move(ctx context.Context, kube client.Client, service clients.Client, mg resource.Managed, parent, name string) error {
+	current := meta.GetExternalName(mg)
+	currentParent, currentName := path.Split(current)
+
+	if strings.Trim(currentParent, "/") != strings.Trim(parent, "/") {
+		if err := service.MoveItem(ctx, current, parent); err != nil {
+			return errors.Wrap(err, errMove)
+		}
+		current = FullName(parent, currentName)
+		meta.SetExternalName(mg, current)
+		if err := UpdateAnnotations(ctx, kube, mg); err != nil {
+			return err
+		}
+	}
+	if currentName != name {
+		if err := service.RenameItem(ctx, current, name); err != nil {
+			return errors.Wrap(err, errRename)
+		}
+		meta.SetExternalName(mg, FullName(parent, name))
+		if err := UpdateAnnotations(ctx, kube, mg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateAnnotations persists the annotations of the supplied managed resource,
+// e.g. its external name. Unlike after Create, the managed reconciler does not
+// persist annotations after Update.
+func UpdateAnnotations(ctx context.Context, kube client.Client, mg resource.Managed) error {
+	return errors.Wrap(managed.NewRetryingCriticalAnnotationUpdater(kube).UpdateCriticalAnnotations(ctx, mg), errUpdateAnnotations)
+}
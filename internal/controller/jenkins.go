@@ -18,8 +18,12 @@ package controller
 
 import (
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/provider-jenkins/internal/controller/credential"
+	"github.com/crossplane/provider-jenkins/internal/controller/folder"
 	"github.com/crossplane/provider-jenkins/internal/controller/jenkinsnode"
 	"github.com/crossplane/provider-jenkins/internal/controller/job"
+	"github.com/crossplane/provider-jenkins/internal/controller/multibranchpipeline"
+	"github.com/crossplane/provider-jenkins/internal/controller/organizationfolder"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/crossplane/provider-jenkins/internal/controller/config"
@@ -30,7 +34,12 @@ import (
 func Setup(mgr ctrl.Manager, o controller.Options) error {
 	for _, setup := range []func(ctrl.Manager, controller.Options) error{
 		config.Setup,
+		config.SetupHealth,
+		folder.Setup,
+		credential.Setup,
 		job.Setup,
+		multibranchpipeline.Setup,
+		organizationfolder.Setup,
 		jenkinsnode.Setup,
 	} {
 		if err := setup(mgr, o); err != nil {
@@ -0,0 +1,545 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jenkinsnode
+
+import (
+	"encoding/xml"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+
+	"github.com/crossplane/provider-jenkins/apis/dashboard/v1alpha1"
+	"github.com/crossplane/provider-jenkins/internal/clients"
+)
+
+// Classes of the launchers and SSH host key verification strategies that can
+// be configured by a JenkinsNode.
+const (
+	inboundLauncherClass = "hudson.slaves.JNLPLauncher"
+	sshLauncherClass     = "hudson.plugins.sshslaves.SSHLauncher"
+	commandLauncherClass = "hudson.slaves.CommandLauncher"
+
+	sshVerifiersPackage = "hudson.plugins.sshslaves.verifiers."
+)
+
+// Classes of the retention strategies and node properties that can be
+// configured by a JenkinsNode.
+const (
+	alwaysRetentionClass    = "hudson.slaves.RetentionStrategy$Always"
+	demandRetentionClass    = "hudson.slaves.RetentionStrategy$Demand"
+	scheduledRetentionClass = "hudson.slaves.SimpleScheduledRetentionStrategy"
+
+	envVarsPropertyClass      = "hudson.slaves.EnvironmentVariablesNodeProperty"
+	toolLocationPropertyClass = "hudson.tools.ToolLocationNodeProperty"
+	toolLocationClass         = "hudson.tools.ToolLocationNodeProperty_-ToolLocation"
+	diskSpacePropertyClass    = "hudson.node_monitors.DiskSpaceMonitorNodeProperty"
+)
+
+// nodeModes maps node modes to their config.xml representation.
+var nodeModes = map[v1alpha1.JenkinsNodeMode]string{
+	v1alpha1.JenkinsNodeModeNormal:    "NORMAL",
+	v1alpha1.JenkinsNodeModeExclusive: "EXCLUSIVE",
+}
+
+// sshHostKeyStrategyClasses maps SSH host key verification strategies to
+// their classes.
+var sshHostKeyStrategyClasses = map[v1alpha1.SSHHostKeyVerificationStrategy]string{
+	v1alpha1.SSHHostKeyKnownHosts:       sshVerifiersPackage + "KnownHostsFileKeyVerificationStrategy",
+	v1alpha1.SSHHostKeyManuallyProvided: sshVerifiersPackage + "ManuallyProvidedKeyVerificationStrategy",
+	v1alpha1.SSHHostKeyManuallyTrusted:  sshVerifiersPackage + "ManuallyTrustedKeyVerificationStrategy",
+	v1alpha1.SSHHostKeyNonVerifying:     sshVerifiersPackage + "NonVerifyingKeyVerificationStrategy",
+}
+
+// nodeConfig is the part of a node's config.xml managed by a JenkinsNode.
+type nodeConfig struct {
+	Description  string         `xml:"description"`
+	RemoteFS     string         `xml:"remoteFS"`
+	NumExecutors int64          `xml:"numExecutors"`
+	Label        string         `xml:"label"`
+	Mode         string         `xml:"mode"`
+	Launcher     launcherConfig `xml:"launcher"`
+
+	RetentionStrategy retentionConfig  `xml:"retentionStrategy"`
+	Properties        propertiesConfig `xml:"nodeProperties"`
+}
+
+// launcherConfig is the part of the launcher of a node managed by a
+// JenkinsNode. Only the fields of the launcher's class are set.
+type launcherConfig struct {
+	Class string `xml:"class,attr"`
+
+	// Inbound launcher.
+	WebSocket bool   `xml:"webSocket"`
+	Tunnel    string `xml:"tunnel"`
+
+	// SSH launcher.
+	Host          string          `xml:"host"`
+	Port          int64           `xml:"port"`
+	CredentialsID string          `xml:"credentialsId"`
+	JVMOptions    string          `xml:"jvmOptions"`
+	JavaPath      string          `xml:"javaPath"`
+	HostKey       hostKeyStrategy `xml:"sshHostKeyVerificationStrategy"`
+
+	// Command launcher.
+	AgentCommand string `xml:"agentCommand"`
+}
+
+// hostKeyStrategy is an SSH host key verification strategy. Only the
+// manually provided strategy has a key.
+type hostKeyStrategy struct {
+	Class     string `xml:"class,attr"`
+	Algorithm string `xml:"key>algorithm"`
+	Key       string `xml:"key>key"`
+}
+
+// retentionConfig is the retention strategy of a node. Only the fields of the
+// strategy's class are set.
+type retentionConfig struct {
+	Class string `xml:"class,attr"`
+
+	// On demand strategy.
+	InDemandDelay int64 `xml:"inDemandDelay"`
+	IdleDelay     int64 `xml:"idleDelay"`
+
+	// Scheduled strategy.
+	StartTimeSpec    string `xml:"startTimeSpec"`
+	UpTimeMins       int64  `xml:"upTimeMins"`
+	KeepUpWhenActive bool   `xml:"keepUpWhenActive"`
+}
+
+// propertiesConfig is the part of the node properties of a node managed by a
+// JenkinsNode.
+type propertiesConfig struct {
+	// EnvVars alternates the names and values of the environment variables,
+	// as serialized by Jenkins.
+	EnvVars       []string         `xml:"hudson.slaves.EnvironmentVariablesNodeProperty>envVars>tree-map>string"`
+	ToolLocations []toolLocation   `xml:"hudson.tools.ToolLocationNodeProperty>locations>hudson.tools.ToolLocationNodeProperty_-ToolLocation"`
+	DiskSpace     *diskSpaceConfig `xml:"hudson.node_monitors.DiskSpaceMonitorNodeProperty"`
+}
+
+type toolLocation struct {
+	Type string `xml:"type"`
+	Name string `xml:"name"`
+	Home string `xml:"home"`
+}
+
+type diskSpaceConfig struct {
+	FreeDiskSpaceThreshold        string `xml:"freeDiskSpaceThreshold"`
+	FreeTempSpaceThreshold        string `xml:"freeTempSpaceThreshold"`
+	FreeDiskSpaceWarningThreshold string `xml:"freeDiskSpaceWarningThreshold"`
+	FreeTempSpaceWarningThreshold string `xml:"freeTempSpaceWarningThreshold"`
+}
+
+// labelString returns the label string of a node with the supplied
+// parameters.
+func labelString(p v1alpha1.JenkinsNodeParameters) string {
+	labels := strings.Fields(p.Label)
+	for _, l := range p.Labels {
+		labels = append(labels, strings.Fields(l)...)
+	}
+	return strings.Join(labels, " ")
+}
+
+// generateRetentionConfig returns the retention strategy config described by
+// the supplied retention strategy.
+func generateRetentionConfig(r v1alpha1.RetentionStrategy) (retentionConfig, error) {
+	switch {
+	case r.Always != nil && r.OnDemand == nil && r.Scheduled == nil:
+		return retentionConfig{Class: alwaysRetentionClass}, nil
+	case r.OnDemand != nil && r.Always == nil && r.Scheduled == nil:
+		return retentionConfig{
+			Class:         demandRetentionClass,
+			InDemandDelay: r.OnDemand.InDemandDelayMinutes,
+			IdleDelay:     r.OnDemand.IdleDelayMinutes,
+		}, nil
+	case r.Scheduled != nil && r.Always == nil && r.OnDemand == nil:
+		return retentionConfig{
+			Class:            scheduledRetentionClass,
+			StartTimeSpec:    r.Scheduled.StartTimeSpec,
+			UpTimeMins:       r.Scheduled.UpTimeMinutes,
+			KeepUpWhenActive: r.Scheduled.KeepUpWhenActive,
+		}, nil
+	}
+	return retentionConfig{}, errors.New("exactly one of always, onDemand or scheduled retention strategy must be specified")
+}
+
+// generatePropertiesConfig returns the node properties config described by
+// the supplied node properties. Environment variables are sorted by name, as
+// Jenkins stores them.
+func generatePropertiesConfig(p v1alpha1.JenkinsNodeProperties) propertiesConfig {
+	cfg := propertiesConfig{}
+	env := make([]v1alpha1.EnvironmentVariable, len(p.EnvironmentVariables))
+	copy(env, p.EnvironmentVariables)
+	sort.SliceStable(env, func(i, j int) bool { return strings.ToUpper(env[i].Name) < strings.ToUpper(env[j].Name) })
+	for _, e := range env {
+		cfg.EnvVars = append(cfg.EnvVars, e.Name, e.Value)
+	}
+	for _, l := range p.ToolLocations {
+		cfg.ToolLocations = append(cfg.ToolLocations, toolLocation{Type: l.Type, Name: l.Name, Home: l.Home})
+	}
+	if d := p.DiskSpaceMonitoring; d != nil {
+		cfg.DiskSpace = &diskSpaceConfig{
+			FreeDiskSpaceThreshold:        d.FreeDiskSpaceThreshold,
+			FreeTempSpaceThreshold:        d.FreeTempSpaceThreshold,
+			FreeDiskSpaceWarningThreshold: d.FreeDiskSpaceWarningThreshold,
+			FreeTempSpaceWarningThreshold: d.FreeTempSpaceWarningThreshold,
+		}
+	}
+	return cfg
+}
+
+// equal reports whether the supplied node properties configs are equal.
+// Environment variable names are case insensitive.
+func (c propertiesConfig) equal(o propertiesConfig) bool {
+	if len(c.EnvVars) != len(o.EnvVars) || len(c.ToolLocations) != len(o.ToolLocations) {
+		return false
+	}
+	for i := 0; i+1 < len(c.EnvVars); i += 2 {
+		if !strings.EqualFold(c.EnvVars[i], o.EnvVars[i]) || c.EnvVars[i+1] != o.EnvVars[i+1] {
+			return false
+		}
+	}
+	for i := range c.ToolLocations {
+		if c.ToolLocations[i] != o.ToolLocations[i] {
+			return false
+		}
+	}
+	if c.DiskSpace == nil || o.DiskSpace == nil {
+		return c.DiskSpace == o.DiskSpace
+	}
+	return *c.DiskSpace == *o.DiskSpace
+}
+
+// generateLauncherConfig returns the launcher config described by the
+// supplied launcher.
+func generateLauncherConfig(l v1alpha1.JenkinsNodeLauncher) (launcherConfig, error) {
+	switch {
+	case l.Inbound != nil && l.SSH == nil && l.Command == nil:
+		return launcherConfig{Class: inboundLauncherClass, WebSocket: l.Inbound.WebSocket, Tunnel: l.Inbound.Tunnel}, nil
+	case l.SSH != nil && l.Inbound == nil && l.Command == nil:
+		return generateSSHLauncherConfig(*l.SSH)
+	case l.Command != nil && l.Inbound == nil && l.SSH == nil:
+		return launcherConfig{Class: commandLauncherClass, AgentCommand: l.Command.Command}, nil
+	}
+	return launcherConfig{}, errors.New("exactly one of inbound, ssh or command launcher must be specified")
+}
+
+// generateSSHLauncherConfig returns the launcher config described by the
+// supplied SSH launcher.
+func generateSSHLauncherConfig(l v1alpha1.SSHLauncher) (launcherConfig, error) {
+	cfg := launcherConfig{
+		Class:         sshLauncherClass,
+		Host:          l.Host,
+		Port:          22,
+		CredentialsID: l.CredentialsID,
+		JVMOptions:    l.JVMOptions,
+		JavaPath:      l.JavaPath,
+	}
+	if l.Port != nil {
+		cfg.Port = *l.Port
+	}
+	strategy := v1alpha1.SSHHostKeyKnownHosts
+	if l.HostKeyVerificationStrategy != nil {
+		strategy = *l.HostKeyVerificationStrategy
+	}
+	class, ok := sshHostKeyStrategyClasses[strategy]
+	if !ok {
+		return launcherConfig{}, errors.Errorf("unknown SSH host key verification strategy %q", strategy)
+	}
+	cfg.HostKey.Class = class
+	if strategy == v1alpha1.SSHHostKeyManuallyProvided {
+		// The host key is in known_hosts format, i.e. the algorithm followed
+		// by the base64 encoded key.
+		f := strings.Fields(l.HostKey)
+		if len(f) < 2 {
+			return launcherConfig{}, errors.New("hostKey must be specified as \"<algorithm> <key>\" when using the ManuallyProvided strategy")
+		}
+		cfg.HostKey.Algorithm, cfg.HostKey.Key = f[0], f[1]
+	}
+	return cfg, nil
+}
+
+// decodeConfig decodes the supplied config.xml of a node.
+func decodeConfig(doc string) (nodeConfig, error) {
+	cfg := nodeConfig{}
+	return cfg, clients.DecodeXML(doc, &cfg)
+}
+
+// isUpToDate reports whether the supplied config of a node matches the
+// supplied parameters. Settings that are omitted from the parameters are not
+// compared.
+func isUpToDate(p v1alpha1.JenkinsNodeParameters, cfg nodeConfig) bool {
+	return cfg.Description == p.Description &&
+		cfg.RemoteFS == p.RemoteFS &&
+		cfg.NumExecutors == p.NumExecutors &&
+		strings.Join(strings.Fields(cfg.Label), " ") == labelString(p) &&
+		(p.Mode == nil || cfg.Mode == nodeModes[*p.Mode]) &&
+		launcherUpToDate(p.Launcher, cfg.Launcher) &&
+		retentionUpToDate(p.RetentionStrategy, cfg.RetentionStrategy) &&
+		(p.NodeProperties == nil || generatePropertiesConfig(*p.NodeProperties).equal(cfg.Properties))
+}
+
+// launcherUpToDate reports whether the supplied launcher config matches the
+// supplied launcher. An invalid launcher is never up to date, so that the
+// error is surfaced by updateConfig.
+func launcherUpToDate(l *v1alpha1.JenkinsNodeLauncher, cfg launcherConfig) bool {
+	if l == nil {
+		return true
+	}
+	desired, err := generateLauncherConfig(*l)
+	return err == nil && desired == cfg
+}
+
+// retentionUpToDate reports whether the supplied retention strategy config
+// matches the supplied retention strategy. An invalid retention strategy is
+// never up to date, so that the error is surfaced by updateConfig.
+func retentionUpToDate(r *v1alpha1.RetentionStrategy, cfg retentionConfig) bool {
+	if r == nil {
+		return true
+	}
+	desired, err := generateRetentionConfig(*r)
+	return err == nil && desired == cfg
+}
+
+// updateConfig returns the supplied config.xml of a node updated to match the
+// supplied parameters. Elements that are not managed by a JenkinsNode, such as
+// those contributed by plugins, are preserved.
+func updateConfig(p v1alpha1.JenkinsNodeParameters, doc string) (string, error) {
+	root := &element{}
+	if err := clients.DecodeXML(doc, root); err != nil {
+		return "", err
+	}
+	root.trim()
+	root.setText("description", p.Description)
+	root.setText("remoteFS", p.RemoteFS)
+	root.setText("numExecutors", strconv.FormatInt(p.NumExecutors, 10))
+	root.setText("label", labelString(p))
+	if p.Mode != nil {
+		root.setText("mode", nodeModes[*p.Mode])
+	}
+	if p.Launcher != nil {
+		l, err := generateLauncherConfig(*p.Launcher)
+		if err != nil {
+			return "", err
+		}
+		setLauncher(root, l)
+	}
+	if p.RetentionStrategy != nil {
+		r, err := generateRetentionConfig(*p.RetentionStrategy)
+		if err != nil {
+			return "", err
+		}
+		root.replace("retentionStrategy", retentionElement(r))
+	}
+	if p.NodeProperties != nil {
+		setProperties(root, generatePropertiesConfig(*p.NodeProperties))
+	}
+
+	b, err := xml.MarshalIndent(root, "", "  ")
+	return string(b), errors.Wrap(err, "cannot encode config.xml")
+}
+
+// setLauncher sets the launcher of the supplied node config. The launcher is
+// replaced if its class changes; otherwise settings not managed by a
+// JenkinsNode, such as timeouts, are preserved.
+func setLauncher(root *element, l launcherConfig) {
+	e := root.child("launcher")
+	if e == nil || e.attr("class") != l.Class {
+		e = newElement("launcher", l.Class)
+		root.replace("launcher", e)
+	}
+	switch l.Class {
+	case inboundLauncherClass:
+		e.setText("webSocket", strconv.FormatBool(l.WebSocket))
+		e.setText("tunnel", l.Tunnel)
+	case sshLauncherClass:
+		e.setText("host", l.Host)
+		e.setText("port", strconv.FormatInt(l.Port, 10))
+		e.setText("credentialsId", l.CredentialsID)
+		e.setText("jvmOptions", l.JVMOptions)
+		e.setText("javaPath", l.JavaPath)
+		s := newElement("sshHostKeyVerificationStrategy", l.HostKey.Class)
+		if l.HostKey.Key != "" {
+			k := s.add(newElement("key", ""))
+			k.setText("algorithm", l.HostKey.Algorithm)
+			k.setText("key", l.HostKey.Key)
+		}
+		e.replace(s.XMLName.Local, s)
+	case commandLauncherClass:
+		e.setText("agentCommand", l.AgentCommand)
+	}
+}
+
+// retentionElement returns the retentionStrategy element of the supplied
+// retention strategy.
+func retentionElement(r retentionConfig) *element {
+	e := newElement("retentionStrategy", r.Class)
+	switch r.Class {
+	case demandRetentionClass:
+		e.setText("inDemandDelay", strconv.FormatInt(r.InDemandDelay, 10))
+		e.setText("idleDelay", strconv.FormatInt(r.IdleDelay, 10))
+	case scheduledRetentionClass:
+		e.setText("startTimeSpec", r.StartTimeSpec)
+		e.setText("upTimeMins", strconv.FormatInt(r.UpTimeMins, 10))
+		e.setText("keepUpWhenActive", strconv.FormatBool(r.KeepUpWhenActive))
+	}
+	return e
+}
+
+// setProperties sets the node properties of the supplied node config that are
+// managed by a JenkinsNode. Properties that are not configured are removed;
+// properties of other kinds are preserved.
+func setProperties(root *element, p propertiesConfig) {
+	props := root.child("nodeProperties")
+	if props == nil {
+		props = root.add(newElement("nodeProperties", ""))
+	}
+	props.remove(envVarsPropertyClass)
+	props.remove(toolLocationPropertyClass)
+	props.remove(diskSpacePropertyClass)
+
+	if len(p.EnvVars) > 0 {
+		// Jenkins serializes environment variables as a case insensitive
+		// TreeMap.
+		m := props.add(newElement(envVarsPropertyClass, "")).add(&element{
+			XMLName: xml.Name{Local: "envVars"},
+			Attrs:   []xml.Attr{{Name: xml.Name{Local: "serialization"}, Value: "custom"}},
+		})
+		m.add(newElement("unserializable-parents", ""))
+		t := m.add(newElement("tree-map", ""))
+		t.add(newElement("default", "")).add(newElement("comparator", "java.lang.String$CaseInsensitiveComparator"))
+		t.add(newElement("int", "")).Text = strconv.Itoa(len(p.EnvVars) / 2)
+		for _, s := range p.EnvVars {
+			t.add(newElement("string", "")).Text = s
+		}
+	}
+	if len(p.ToolLocations) > 0 {
+		locations := props.add(newElement(toolLocationPropertyClass, "")).add(newElement("locations", ""))
+		for _, l := range p.ToolLocations {
+			e := locations.add(newElement(toolLocationClass, ""))
+			e.setText("type", l.Type)
+			e.setText("name", l.Name)
+			e.setText("home", l.Home)
+		}
+	}
+	if d := p.DiskSpace; d != nil {
+		// Jenkins cannot parse empty thresholds, so they are omitted.
+		e := props.add(newElement(diskSpacePropertyClass, ""))
+		for _, t := range [][2]string{
+			{"freeDiskSpaceThreshold", d.FreeDiskSpaceThreshold},
+			{"freeTempSpaceThreshold", d.FreeTempSpaceThreshold},
+			{"freeDiskSpaceWarningThreshold", d.FreeDiskSpaceWarningThreshold},
+			{"freeTempSpaceWarningThreshold", d.FreeTempSpaceWarningThreshold},
+		} {
+			if t[1] != "" {
+				e.setText(t[0], t[1])
+			}
+		}
+	}
+}
+
+// An element is a generic element of a config.xml. Jenkins does not use mixed
+// content, so an element has either text or child elements.
+type element struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Text     string     `xml:",chardata"`
+	Children []*element `xml:",any"`
+}
+
+// trim removes the whitespace between child elements.
+func (e *element) trim() {
+	if len(e.Children) > 0 {
+		e.Text = ""
+	}
+	for _, c := range e.Children {
+		c.trim()
+	}
+}
+
+// child returns the first child element with the supplied name, or nil.
+func (e *element) child(name string) *element {
+	for _, c := range e.Children {
+		if c.XMLName.Local == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// newElement returns an element with the supplied name and, unless it is
+// empty, class attribute.
+func newElement(name, class string) *element {
+	e := &element{XMLName: xml.Name{Local: name}}
+	if class != "" {
+		e.Attrs = []xml.Attr{{Name: xml.Name{Local: "class"}, Value: class}}
+	}
+	return e
+}
+
+// add appends the supplied child element and returns it.
+func (e *element) add(c *element) *element {
+	e.Children = append(e.Children, c)
+	return c
+}
+
+// remove removes all child elements with the supplied name.
+func (e *element) remove(name string) {
+	children := e.Children[:0]
+	for _, c := range e.Children {
+		if c.XMLName.Local != name {
+			children = append(children, c)
+		}
+	}
+	e.Children = children
+}
+
+// attr returns the value of the attribute with the supplied name, or an empty
+// string.
+func (e *element) attr(name string) string {
+	for _, a := range e.Attrs {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// replace replaces the first child element with the supplied name, adding the
+// supplied element if there is none.
+func (e *element) replace(name string, c *element) {
+	for i := range e.Children {
+		if e.Children[i].XMLName.Local == name {
+			e.Children[i] = c
+			return
+		}
+	}
+	e.Children = append(e.Children, c)
+}
+
+// setText sets the text of the child element with the supplied name, adding
+// the element if necessary.
+func (e *element) setText(name, text string) {
+	c := e.child(name)
+	if c == nil {
+		c = &element{XMLName: xml.Name{Local: name}}
+		e.Children = append(e.Children, c)
+	}
+	c.Text = text
+}
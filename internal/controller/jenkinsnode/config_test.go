@@ -0,0 +1,323 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jenkinsnode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-jenkins/apis/dashboard/v1alpha1"
+	"github.com/crossplane/provider-jenkins/internal/clients"
+)
+
+// observedConfig is the config.xml of an SSH node, including settings that are
+// not managed by a JenkinsNode.
+const observedConfig = `<?xml version='1.1' encoding='UTF-8'?>
+<slave>
+  <name>agent</name>
+  <description>old</description>
+  <remoteFS>/home/jenkins</remoteFS>
+  <numExecutors>1</numExecutors>
+  <mode>EXCLUSIVE</mode>
+  <retentionStrategy class="hudson.slaves.RetentionStrategy$Always"/>
+  <launcher class="hudson.plugins.sshslaves.SSHLauncher" plugin="ssh-slaves@2.0">
+    <host>old.example.org</host>
+    <port>22</port>
+    <credentialsId>ssh</credentialsId>
+    <launchTimeoutSeconds>60</launchTimeoutSeconds>
+    <sshHostKeyVerificationStrategy class="hudson.plugins.sshslaves.verifiers.NonVerifyingKeyVerificationStrategy"/>
+  </launcher>
+  <label>linux</label>
+  <nodeProperties>
+    <hudson.tools.ToolLocationNodeProperty>
+      <locations>
+        <hudson.tools.ToolLocationNodeProperty_-ToolLocation>
+          <type>hudson.model.JDK$DescriptorImpl</type>
+          <name>jdk</name>
+          <home>/opt/jdk</home>
+        </hudson.tools.ToolLocationNodeProperty_-ToolLocation>
+      </locations>
+    </hudson.tools.ToolLocationNodeProperty>
+    <com.example.PluginNodeProperty plugin="example@1.0">
+      <setting>kept</setting>
+    </com.example.PluginNodeProperty>
+  </nodeProperties>
+</slave>`
+
+// toolLocationProperty is the tool location node property of observedConfig.
+const toolLocationProperty = `<hudson.tools.ToolLocationNodeProperty>
+      <locations>
+        <hudson.tools.ToolLocationNodeProperty_-ToolLocation>
+          <type>hudson.model.JDK$DescriptorImpl</type>
+          <name>jdk</name>
+          <home>/opt/jdk</home>
+        </hudson.tools.ToolLocationNodeProperty_-ToolLocation>
+      </locations>
+    </hudson.tools.ToolLocationNodeProperty>`
+
+// envVarsProperty is the environment variables node property of the
+// variables PATH and java_home, which Jenkins sorts case insensitively.
+const envVarsProperty = `<hudson.slaves.EnvironmentVariablesNodeProperty><envVars serialization="custom">
+      <unserializable-parents></unserializable-parents>
+      <tree-map>
+        <default><comparator class="java.lang.String$CaseInsensitiveComparator"></comparator></default>
+        <int>2</int>
+        <string>java_home</string><string>/opt/jdk</string>
+        <string>PATH</string><string>/bin</string>
+      </tree-map>
+    </envVars></hudson.slaves.EnvironmentVariablesNodeProperty>`
+
+// params returns the parameters matching observedConfig, modified by the
+// supplied functions.
+func params(m ...func(*v1alpha1.JenkinsNodeParameters)) v1alpha1.JenkinsNodeParameters {
+	p := v1alpha1.JenkinsNodeParameters{Name: "agent", NumExecutors: 1, Description: "old", RemoteFS: "/home/jenkins", Label: "linux"}
+	for _, f := range m {
+		f(&p)
+	}
+	return p
+}
+
+// sshLauncher returns the SSH launcher of observedConfig.
+func sshLauncher() *v1alpha1.JenkinsNodeLauncher {
+	s := v1alpha1.SSHHostKeyNonVerifying
+	return &v1alpha1.JenkinsNodeLauncher{SSH: &v1alpha1.SSHLauncher{Host: "old.example.org", CredentialsID: "ssh", HostKeyVerificationStrategy: &s}}
+}
+
+func TestUpdateConfig(t *testing.T) {
+	type want struct {
+		config string
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.JenkinsNodeParameters
+		want   want
+	}{
+		"Unchanged": {
+			reason: "A config.xml that matches should not be changed.",
+			p:      params(),
+			want:   want{config: observedConfig},
+		},
+		"ManagedFieldsUpdated": {
+			reason: "Managed fields should be updated in place.",
+			p: params(func(p *v1alpha1.JenkinsNodeParameters) {
+				p.Description, p.NumExecutors, p.Labels = "new", 2, []string{"docker"}
+				m := v1alpha1.JenkinsNodeModeNormal
+				p.Mode = &m
+			}),
+			want: want{config: strings.NewReplacer(
+				"<description>old</description>", "<description>new</description>",
+				"<numExecutors>1</numExecutors>", "<numExecutors>2</numExecutors>",
+				"<mode>EXCLUSIVE</mode>", "<mode>NORMAL</mode>",
+				"<label>linux</label>", "<label>linux docker</label>",
+			).Replace(observedConfig)},
+		},
+		"LauncherUpdated": {
+			reason: "A launcher of the same class should be updated, preserving settings that are not managed.",
+			p: params(func(p *v1alpha1.JenkinsNodeParameters) {
+				p.Launcher = sshLauncher()
+				p.Launcher.SSH.Host = "new.example.org"
+				p.Launcher.SSH.HostKeyVerificationStrategy = nil
+			}),
+			want: want{config: strings.NewReplacer(
+				"<host>old.example.org</host>", "<host>new.example.org</host>",
+				`<sshHostKeyVerificationStrategy class="hudson.plugins.sshslaves.verifiers.NonVerifyingKeyVerificationStrategy"/>`,
+				`<sshHostKeyVerificationStrategy class="hudson.plugins.sshslaves.verifiers.KnownHostsFileKeyVerificationStrategy"/><jvmOptions></jvmOptions><javaPath></javaPath>`,
+			).Replace(observedConfig)},
+		},
+		"LauncherReplaced": {
+			reason: "A launcher of a different class should be replaced.",
+			p: params(func(p *v1alpha1.JenkinsNodeParameters) {
+				p.Launcher = &v1alpha1.JenkinsNodeLauncher{Inbound: &v1alpha1.InboundLauncher{WebSocket: true}}
+			}),
+			want: want{config: replaceElement(observedConfig, "launcher",
+				`<launcher class="hudson.slaves.JNLPLauncher"><webSocket>true</webSocket><tunnel></tunnel></launcher>`)},
+		},
+		"RetentionStrategyReplaced": {
+			reason: "The retention strategy should be replaced.",
+			p: params(func(p *v1alpha1.JenkinsNodeParameters) {
+				p.RetentionStrategy = &v1alpha1.RetentionStrategy{OnDemand: &v1alpha1.OnDemandRetentionStrategy{InDemandDelayMinutes: 1, IdleDelayMinutes: 5}}
+			}),
+			want: want{config: strings.Replace(observedConfig,
+				`<retentionStrategy class="hudson.slaves.RetentionStrategy$Always"/>`,
+				`<retentionStrategy class="hudson.slaves.RetentionStrategy$Demand"><inDemandDelay>1</inDemandDelay><idleDelay>5</idleDelay></retentionStrategy>`, 1)},
+		},
+		"NodePropertiesUpdated": {
+			reason: "Managed node properties should be replaced, and properties of other kinds preserved.",
+			p: params(func(p *v1alpha1.JenkinsNodeParameters) {
+				p.NodeProperties = &v1alpha1.JenkinsNodeProperties{EnvironmentVariables: []v1alpha1.EnvironmentVariable{{Name: "PATH", Value: "/bin"}, {Name: "java_home", Value: "/opt/jdk"}}}
+			}),
+			want: want{config: strings.NewReplacer(
+				toolLocationProperty, "",
+				"</nodeProperties>", envVarsProperty+"</nodeProperties>",
+			).Replace(observedConfig)},
+		},
+		"InvalidLauncher": {
+			reason: "We should return an error if the launcher is invalid.",
+			p: params(func(p *v1alpha1.JenkinsNodeParameters) {
+				p.Launcher = &v1alpha1.JenkinsNodeLauncher{}
+			}),
+			want: want{err: errors.New("exactly one of inbound, ssh or command launcher must be specified")},
+		},
+		"InvalidRetentionStrategy": {
+			reason: "We should return an error if the retention strategy is invalid.",
+			p: params(func(p *v1alpha1.JenkinsNodeParameters) {
+				p.RetentionStrategy = &v1alpha1.RetentionStrategy{}
+			}),
+			want: want{err: errors.New("exactly one of always, onDemand or scheduled retention strategy must be specified")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := updateConfig(tc.p, observedConfig)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nupdateConfig(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if tc.want.err != nil {
+				return
+			}
+			if !clients.EqualXML(tc.want.config, got, false) {
+				t.Errorf("\n%s\nupdateConfig(...): want config.xml:\n%s\ngot config.xml:\n%s", tc.reason, tc.want.config, got)
+			}
+
+			// An updated config.xml must be up to date.
+			cfg, err := decodeConfig(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !isUpToDate(tc.p, cfg) {
+				t.Errorf("\n%s\nisUpToDate(...): updated config.xml is not up to date:\n%s", tc.reason, got)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		p        v1alpha1.JenkinsNodeParameters
+		observed string
+		want     bool
+	}{
+		"UpToDate": {
+			reason:   "A config.xml that matches should be up to date.",
+			p:        params(),
+			observed: observedConfig,
+			want:     true,
+		},
+		"Labels": {
+			reason:   "Labels should be compared regardless of how they are split and spaced.",
+			p:        params(func(p *v1alpha1.JenkinsNodeParameters) { p.Label, p.Labels = "", []string{"linux", "docker"} }),
+			observed: strings.Replace(observedConfig, "<label>linux</label>", "<label>linux\n  docker</label>", 1),
+			want:     true,
+		},
+		"DescriptionChanged": {
+			reason:   "A config.xml whose description differs should not be up to date.",
+			p:        params(func(p *v1alpha1.JenkinsNodeParameters) { p.Description = "new" }),
+			observed: observedConfig,
+			want:     false,
+		},
+		"ModeOmitted": {
+			reason:   "The mode should not be compared if it is omitted.",
+			p:        params(),
+			observed: observedConfig,
+			want:     true,
+		},
+		"ModeChanged": {
+			reason: "A config.xml whose mode differs should not be up to date.",
+			p: params(func(p *v1alpha1.JenkinsNodeParameters) {
+				m := v1alpha1.JenkinsNodeModeNormal
+				p.Mode = &m
+			}),
+			observed: observedConfig,
+			want:     false,
+		},
+		"LauncherUpToDate": {
+			reason:   "A launcher that matches should be up to date, ignoring settings that are not managed.",
+			p:        params(func(p *v1alpha1.JenkinsNodeParameters) { p.Launcher = sshLauncher() }),
+			observed: observedConfig,
+			want:     true,
+		},
+		"LauncherChanged": {
+			reason: "A config.xml whose launcher differs should not be up to date.",
+			p: params(func(p *v1alpha1.JenkinsNodeParameters) {
+				p.Launcher = sshLauncher()
+				p.Launcher.SSH.Host = "new.example.org"
+			}),
+			observed: observedConfig,
+			want:     false,
+		},
+		"InvalidLauncher": {
+			reason:   "An invalid launcher should never be up to date.",
+			p:        params(func(p *v1alpha1.JenkinsNodeParameters) { p.Launcher = &v1alpha1.JenkinsNodeLauncher{} }),
+			observed: observedConfig,
+			want:     false,
+		},
+		"RetentionStrategyUpToDate": {
+			reason: "A retention strategy that matches should be up to date.",
+			p: params(func(p *v1alpha1.JenkinsNodeParameters) {
+				p.RetentionStrategy = &v1alpha1.RetentionStrategy{Always: &v1alpha1.AlwaysRetentionStrategy{}}
+			}),
+			observed: observedConfig,
+			want:     true,
+		},
+		"NodePropertiesUpToDate": {
+			reason: "Node properties that match should be up to date, ignoring properties of other kinds.",
+			p: params(func(p *v1alpha1.JenkinsNodeParameters) {
+				p.NodeProperties = &v1alpha1.JenkinsNodeProperties{ToolLocations: []v1alpha1.ToolLocation{{Type: "hudson.model.JDK$DescriptorImpl", Name: "jdk", Home: "/opt/jdk"}}}
+			}),
+			observed: observedConfig,
+			want:     true,
+		},
+		"NodePropertiesChanged": {
+			reason: "A config.xml whose node properties differ should not be up to date.",
+			p: params(func(p *v1alpha1.JenkinsNodeParameters) {
+				p.NodeProperties = &v1alpha1.JenkinsNodeProperties{}
+			}),
+			observed: observedConfig,
+			want:     false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := decodeConfig(tc.observed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := isUpToDate(tc.p, cfg)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nisUpToDate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// replaceElement replaces the first element with the supplied name in the
+// supplied document.
+func replaceElement(doc, name, replacement string) string {
+	start := strings.Index(doc, "<"+name+" ")
+	end := strings.Index(doc, "</"+name+">") + len("</"+name+">")
+	return doc[:start] + replacement + doc[end:]
+}
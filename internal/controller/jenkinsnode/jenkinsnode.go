@@ -18,9 +18,9 @@ package jenkinsnode
 
 import (
 	"context"
-	"fmt"
 
 	jenkins "github.com/bndr/gojenkins"
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -41,7 +41,30 @@ import (
 
 const (
 	errNotJenkinsNode = "managed resource is not a JenkinsNode custom resource"
+	errNewClient      = "cannot create Jenkins client"
 	errTrackPCUsage   = "cannot track ProviderConfig usage"
+	errGetNode        = "cannot get Jenkins node"
+	errGetNodeConfig  = "cannot get Jenkins node config"
+	errDecodeConfig   = "cannot decode Jenkins node config"
+	errCreateNode     = "cannot create Jenkins node"
+	errUpdateNode     = "cannot update Jenkins node config"
+	errDeleteNode     = "cannot delete Jenkins node"
+	errGetNodeAgent   = "cannot get Jenkins node inbound agent"
+	errToggleOffline  = "cannot toggle Jenkins node offline"
+)
+
+// Connection detail keys. They match the environment variables of the
+// jenkins/inbound-agent image, so the connection secret can be consumed with
+// envFrom.
+const (
+	connectionKeySecret    = "JENKINS_SECRET"
+	connectionKeyURL       = "JENKINS_URL"
+	connectionKeyAgentName = "JENKINS_AGENT_NAME"
+)
+
+// Event reasons.
+const (
+	reasonCannotGetNodeAgent event.Reason = "CannotGetNodeAgent"
 )
 
 // Setup adds a controller that reconciles JenkinsNode managed resources.
@@ -53,14 +76,21 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
 	}
 
+	cc := clients.NewClientCache(clients.NewClient)
+	if err := cc.EvictOnDelete(context.Background(), mgr.GetCache()); err != nil {
+		return err
+	}
+
+	record := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.JenkinsNodeGroupVersionKind),
 		managed.WithExternalConnecter(&connector{
-			kube:         mgr.GetClient(),
-			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
-			newServiceFn: clients.NewClient}),
+			kube:    mgr.GetClient(),
+			usage:   resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			clients: cc,
+			record:  record}),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithRecorder(record),
 		managed.WithConnectionPublishers(cps...))
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -73,16 +103,18 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 // A connector is expected to produce an ExternalClient when its Connect method
 // is called.
 type connector struct {
-	kube         client.Client
-	usage        resource.Tracker
-	newServiceFn func(c clients.Config) *jenkins.Jenkins
+	kube    client.Client
+	usage   resource.Tracker
+	clients *clients.ClientCache
+	record  event.Recorder
 }
 
 // Connect typically produces an ExternalClient by:
 // 1. Tracking that the managed resource is using a ProviderConfig.
 // 2. Getting the managed resource's ProviderConfig.
 // 3. Getting the credentials specified by the ProviderConfig.
-// 4. Using the credentials to form a client.
+// 4. Using the credentials to form a client, or reusing a cached client if
+// neither the ProviderConfig nor its credentials changed.
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
 	cr, ok := mg.(*v1alpha1.JenkinsNode)
 	if !ok {
@@ -93,12 +125,11 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errTrackPCUsage)
 	}
 
-	cfg, err := clients.GetConfig(ctx, c.kube, cr)
+	svc, err := c.clients.Get(ctx, c.kube, cr)
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrap(err, errNewClient)
 	}
-	fmt.Println("\n\nConnect Completed")
-	return &external{kube: c.kube, service: c.newServiceFn(*cfg)}, nil
+	return &external{kube: c.kube, service: svc, record: c.record}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
@@ -107,7 +138,80 @@ type external struct {
 	// A 'client' used to connect to the external resource API. In practice this
 	// would be something like an AWS SDK client.
 	kube    client.Client
-	service *jenkins.Jenkins
+	service clients.Client
+	record  event.Recorder
+}
+
+// generateObservation produces a JenkinsNodeObservation from the config of a
+// node and the state of its computer as reported by Jenkins.
+func generateObservation(name string, cfg nodeConfig, node *jenkins.NodeResponse) v1alpha1.JenkinsNodeObservation {
+	o := v1alpha1.JenkinsNodeObservation{
+		Name:               name,
+		NumExecutors:       cfg.NumExecutors,
+		Description:        cfg.Description,
+		RemoteFS:           cfg.RemoteFS,
+		Label:              cfg.Label,
+		Offline:            node.Offline,
+		TemporarilyOffline: node.TemporarilyOffline,
+		OfflineCause:       node.OfflineCauseReason,
+		JNLPAgent:          node.JnlpAgent,
+	}
+	for _, e := range node.Executors {
+		if e.CurrentExecutable.URL != "" {
+			o.BusyExecutors++
+		}
+	}
+	if idle := node.NumExecutors - o.BusyExecutors; idle > 0 {
+		o.IdleExecutors = idle
+	}
+	// Jenkins only reports the computer idle once none of its executors,
+	// including the one-off executors of flyweight tasks, is running a build.
+	o.Drained = o.TemporarilyOffline && node.Idle && o.BusyExecutors == 0
+
+	// Monitors report nothing while a node is offline.
+	m := node.MonitorData
+	if disk, ok := m.Hudson_NodeMonitors_DiskSpaceMonitor.(map[string]interface{}); ok {
+		if size, ok := disk["size"].(float64); ok {
+			o.FreeDiskSpaceBytes = pointer(int64(size))
+		}
+	}
+	if m.Hudson_NodeMonitors_ResponseTimeMonitor.Average > 0 {
+		o.ResponseTimeMillis = pointer(m.Hudson_NodeMonitors_ResponseTimeMonitor.Average)
+	}
+	if arch, ok := m.Hudson_NodeMonitors_ArchitectureMonitor.(string); ok {
+		o.Architecture = arch
+	}
+	return o
+}
+
+// availability returns the Ready condition of a node with the supplied
+// observation. A node that has been offline since it was created is still
+// waiting for its agent to connect for the first time.
+func availability(current xpv1.Condition, o v1alpha1.JenkinsNodeObservation) xpv1.Condition {
+	if !o.Offline {
+		return xpv1.Available()
+	}
+	if current.Reason == xpv1.ReasonCreating && !o.TemporarilyOffline && o.OfflineCause == "" {
+		return xpv1.Creating()
+	}
+	cause := o.OfflineCause
+	if cause == "" {
+		cause = "node is offline"
+	}
+	return xpv1.Unavailable().WithMessage(cause)
+}
+
+// isOfflineUpToDate reports whether the supplied observation of a node
+// matches the offline state of the supplied parameters.
+func isOfflineUpToDate(p v1alpha1.JenkinsNodeParameters, o v1alpha1.JenkinsNodeObservation) bool {
+	if !p.Offline {
+		return !o.TemporarilyOffline
+	}
+	return o.TemporarilyOffline && o.OfflineCause == p.OfflineMessage
+}
+
+func pointer(v int64) *int64 {
+	return &v
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -116,27 +220,41 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNotJenkinsNode)
 	}
 
-	// These fmt statements should be removed in the real implementation.
-	fmt.Printf("Observing: %+v", cr)
-
 	forProvider := &cr.Spec.ForProvider
 	node, err := c.service.GetNode(ctx, forProvider.Name)
-	switch {
-	case err != nil && err.Error() == "No node found":
-		fmt.Println("404 Node Cannot Found: " + forProvider.Name)
-		return managed.ExternalObservation{ResourceExists: false}, nil // trigger Create
-
-	case err != nil:
-		fmt.Println("\nGet Node Error: " + err.Error())
+	if err != nil {
+		if clients.IsNotFound(err) {
+			return managed.ExternalObservation{ResourceExists: false}, nil // trigger Create
+		}
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetNode)
+	}
 
-	default:
-		nodeIsOnline, _ := node.IsOnline(ctx)
-		if nodeIsOnline {
-			fmt.Println("Node is Online")
+	doc, err := c.service.GetNodeConfig(ctx, forProvider.Name)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetNodeConfig)
+	}
+	config, err := decodeConfig(doc)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errDecodeConfig)
+	}
+	cr.Status.AtProvider = generateObservation(forProvider.Name, config, node)
+	cr.SetConditions(availability(cr.GetCondition(xpv1.TypeReady), cr.Status.AtProvider))
+	upToDate := isUpToDate(*forProvider, config) && isOfflineUpToDate(*forProvider, cr.Status.AtProvider)
+
+	// Only inbound agents have a secret, and fetching it is only worthwhile
+	// if the connection details are published. The secret is not needed to
+	// manage the node, so failing to fetch it, e.g. because the user lacks the
+	// Computer/Connect permission, must not block updates or deletion.
+	details := managed.ConnectionDetails{}
+	if node.JnlpAgent && (cr.GetWriteConnectionSecretToReference() != nil || cr.GetPublishConnectionDetailsTo() != nil) {
+		agent, err := c.service.GetNodeAgent(ctx, forProvider.Name)
+		if err != nil {
+			c.record.Event(cr, event.Warning(reasonCannotGetNodeAgent, errors.Wrap(err, errGetNodeAgent)))
 		} else {
-			fmt.Println("Node is Offline")
+			details[connectionKeySecret] = []byte(agent.Secret)
+			details[connectionKeyURL] = []byte(agent.URL)
+			details[connectionKeyAgentName] = []byte(forProvider.Name)
 		}
-		fmt.Print("\nNode Exist: " + node.GetName() + " Everything OK\n\n")
 	}
 
 	return managed.ExternalObservation{
@@ -148,11 +266,11 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		// Return false when the external resource exists, but it not up to date
 		// with the desired managed resource state. This lets the managed
 		// resource reconciler know that it needs to call Update.
-		ResourceUpToDate: true,
+		ResourceUpToDate: upToDate,
 
 		// Return any details that may be required to connect to the external
 		// resource. These will be stored as the connection secret.
-		ConnectionDetails: managed.ConnectionDetails{},
+		ConnectionDetails: details,
 	}, nil
 }
 
@@ -162,15 +280,19 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errNotJenkinsNode)
 	}
 
-	fmt.Printf("Creating: %+v", cr)
-
 	forProvider := &cr.Spec.ForProvider
-	node, err := c.service.CreateNode(ctx, forProvider.Name, int(forProvider.NumExecutors), forProvider.Description, forProvider.RemoteFS, forProvider.Label)
+	if err := c.service.CreateNode(ctx, forProvider.Name, int(forProvider.NumExecutors), forProvider.Description, forProvider.RemoteFS, labelString(*forProvider)); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateNode)
+	}
 
-	if err != nil {
-		fmt.Println("\nCreating Node Error " + err.Error())
-	} else {
-		fmt.Println("\nNode Successfully Created:  ", node.GetName())
+	// Nodes are created with an inbound agent launcher, the Always retention
+	// strategy and no node properties. Everything else is configured through
+	// the config.xml of the new node.
+	if err := c.configure(ctx, *forProvider); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateNode)
+	}
+	if err := c.setOffline(ctx, *forProvider); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errToggleOffline)
 	}
 
 	return managed.ExternalCreation{
@@ -186,7 +308,12 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errNotJenkinsNode)
 	}
 
-	fmt.Printf("Updating: %+v", cr)
+	if err := c.configure(ctx, cr.Spec.ForProvider); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateNode)
+	}
+	if err := c.setOffline(ctx, cr.Spec.ForProvider); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errToggleOffline)
+	}
 
 	return managed.ExternalUpdate{
 		// Optionally return any details that may be required to connect to the
@@ -195,30 +322,45 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	}, nil
 }
 
+// configure updates the config.xml of the node to match the supplied
+// parameters. The config.xml is read back rather than generated so that
+// settings not managed by the JenkinsNode are preserved.
+func (c *external) configure(ctx context.Context, p v1alpha1.JenkinsNodeParameters) error {
+	config, err := c.service.GetNodeConfig(ctx, p.Name)
+	if err != nil {
+		return errors.Wrap(err, errGetNodeConfig)
+	}
+	config, err = updateConfig(p, config)
+	if err != nil {
+		return err
+	}
+	return c.service.UpdateNodeConfig(ctx, p.Name, config)
+}
+
+// setOffline marks the node temporarily offline or back online to match the
+// supplied parameters. Jenkins only supports toggling the offline state, so the
+// current state is read first.
+func (c *external) setOffline(ctx context.Context, p v1alpha1.JenkinsNodeParameters) error {
+	node, err := c.service.GetNode(ctx, p.Name)
+	if err != nil {
+		return errors.Wrap(err, errGetNode)
+	}
+	switch {
+	case node.TemporarilyOffline != p.Offline:
+		return c.service.ToggleNodeOffline(ctx, p.Name, p.OfflineMessage)
+	case p.Offline && node.OfflineCauseReason != p.OfflineMessage:
+		return c.service.ChangeNodeOfflineCause(ctx, p.Name, p.OfflineMessage)
+	}
+	return nil
+}
+
 func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 	cr, ok := mg.(*v1alpha1.JenkinsNode)
 	if !ok {
 		return errors.New(errNotJenkinsNode)
 	}
 
-	fmt.Printf("Deleting: %+v", cr)
-
 	forProvider := &cr.Spec.ForProvider
-	node, err := c.service.GetNode(ctx, forProvider.Name)
-	if err != nil && err.Error() == "No node found" || node == nil {
-		fmt.Println("404 Node Cannot Found: " + forProvider.Name)
-	} else {
-		result, err := c.service.DeleteNode(ctx, forProvider.Name)
-		if err != nil {
-			fmt.Print("Deleting Node Error: ", err.Error())
-		} else {
-			if !result {
-				fmt.Print("Node Successfully Deleted")
-			} else {
-				fmt.Print("Failed to Delete Node")
-			}
-		}
-	}
-
-	return nil
+	err := c.service.DeleteNode(ctx, forProvider.Name)
+	return errors.Wrap(resource.Ignore(clients.IsNotFound, err), errDeleteNode)
 }
@@ -0,0 +1,484 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jenkinsnode
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-jenkins/apis/dashboard/v1alpha1"
+	"github.com/crossplane/provider-jenkins/internal/clients"
+	"github.com/crossplane/provider-jenkins/internal/clients/fake"
+)
+
+// nodeConfigWithPlugin is the config.xml of a node with an element that is
+// not managed by a JenkinsNode.
+const nodeConfigWithPlugin = `<?xml version='1.1' encoding='UTF-8'?>
+<slave>
+  <name>agent</name>
+  <description>old</description>
+  <remoteFS>/home/jenkins</remoteFS>
+  <numExecutors>1</numExecutors>
+  <mode>EXCLUSIVE</mode>
+  <label></label>
+  <launcher class="hudson.slaves.JNLPLauncher"/>
+  <plugin.Setting>kept</plugin.Setting>
+</slave>`
+
+type nodeModifier func(*v1alpha1.JenkinsNode)
+
+func withDescription(d string) nodeModifier {
+	return func(n *v1alpha1.JenkinsNode) { n.Spec.ForProvider.Description = d }
+}
+
+func withOffline(message string) nodeModifier {
+	return func(n *v1alpha1.JenkinsNode) {
+		n.Spec.ForProvider.Offline = true
+		n.Spec.ForProvider.OfflineMessage = message
+	}
+}
+
+func withMode(m v1alpha1.JenkinsNodeMode) nodeModifier {
+	return func(n *v1alpha1.JenkinsNode) { n.Spec.ForProvider.Mode = &m }
+}
+
+func withLabels(l ...string) nodeModifier {
+	return func(n *v1alpha1.JenkinsNode) { n.Spec.ForProvider.Labels = l }
+}
+
+func withConditions(c ...xpv1.Condition) nodeModifier {
+	return func(n *v1alpha1.JenkinsNode) { n.SetConditions(c...) }
+}
+
+func withConnectionSecret() nodeModifier {
+	return func(n *v1alpha1.JenkinsNode) {
+		n.SetWriteConnectionSecretToReference(&xpv1.SecretReference{Name: "agent", Namespace: "default"})
+	}
+}
+
+func jenkinsNode(m ...nodeModifier) *v1alpha1.JenkinsNode {
+	cr := &v1alpha1.JenkinsNode{Spec: v1alpha1.JenkinsNodeSpec{ForProvider: v1alpha1.JenkinsNodeParameters{
+		Name:         "agent",
+		NumExecutors: 1,
+		Description:  "agent",
+		RemoteFS:     "/home/jenkins",
+	}}}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+// node is the node matching the JenkinsNode returned by jenkinsNode.
+func node() fake.Node {
+	return fake.Node{NumExecutors: 1, Description: "agent", RemoteFS: "/home/jenkins"}
+}
+
+// newExternal returns an external client connected to a new fake Jenkins
+// server.
+func newExternal(t *testing.T) (*external, *fake.Server) {
+	t.Helper()
+	s := fake.NewServer()
+	t.Cleanup(s.Close)
+	svc, err := clients.NewClient(s.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &external{kube: &test.MockClient{}, service: svc, record: event.NewNopRecorder()}, s
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		o         managed.ExternalObservation
+		condition xpv1.Condition
+		err       error
+	}
+
+	offline := node()
+	offline.TemporarilyOffline, offline.OfflineCause = true, "maintenance"
+	waiting := node()
+	waiting.Offline = true
+	changed := node()
+	changed.Description = "changed"
+	denied := node()
+	denied.DenyConnect = true
+
+	cases := map[string]struct {
+		reason string
+		nodes  map[string]fake.Node
+		mg     resource.Managed
+		want   want
+	}{
+		"NotJenkinsNode": {
+			reason: "We should return an error if the managed resource is not a JenkinsNode.",
+			want:   want{err: errors.New(errNotJenkinsNode)},
+		},
+		"NotFound": {
+			reason: "A JenkinsNode whose node does not exist should be created.",
+			mg:     jenkinsNode(),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"UpToDate": {
+			reason: "A JenkinsNode whose node matches and is online should be up to date and available.",
+			nodes:  map[string]fake.Node{"agent": node()},
+			mg:     jenkinsNode(),
+			want: want{
+				o:         managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+				condition: xpv1.Available(),
+			},
+		},
+		"ConfigChanged": {
+			reason: "A JenkinsNode whose node differs should be updated.",
+			nodes:  map[string]fake.Node{"agent": changed},
+			mg:     jenkinsNode(),
+			want: want{
+				o:         managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: managed.ConnectionDetails{}},
+				condition: xpv1.Available(),
+			},
+		},
+		"WaitingForAgent": {
+			reason: "A new JenkinsNode whose agent never connected should still be creating.",
+			nodes:  map[string]fake.Node{"agent": waiting},
+			mg:     jenkinsNode(withConditions(xpv1.Creating())),
+			want: want{
+				o:         managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+				condition: xpv1.Creating(),
+			},
+		},
+		"TemporarilyOffline": {
+			reason: "A JenkinsNode that was taken offline with the desired message should be up to date but unavailable.",
+			nodes:  map[string]fake.Node{"agent": offline},
+			mg:     jenkinsNode(withOffline("maintenance")),
+			want: want{
+				o:         managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+				condition: xpv1.Unavailable().WithMessage("maintenance"),
+			},
+		},
+		"OfflineMessageChanged": {
+			reason: "A JenkinsNode whose offline message differs should be updated.",
+			nodes:  map[string]fake.Node{"agent": offline},
+			mg:     jenkinsNode(withOffline("upgrade")),
+			want: want{
+				o:         managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: managed.ConnectionDetails{}},
+				condition: xpv1.Unavailable().WithMessage("maintenance"),
+			},
+		},
+		"ConnectionDetails": {
+			reason: "The agent secret of an inbound node should be published if a connection secret is written.",
+			nodes:  map[string]fake.Node{"agent": node()},
+			mg:     jenkinsNode(withConnectionSecret()),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{
+					connectionKeySecret:    []byte(fake.AgentSecret("agent")),
+					connectionKeyAgentName: []byte("agent"),
+				}},
+				condition: xpv1.Available(),
+			},
+		},
+		"ConnectionDetailsForbidden": {
+			reason: "A JenkinsNode whose agent secret cannot be fetched should still be observed, without connection details.",
+			nodes:  map[string]fake.Node{"agent": denied},
+			mg:     jenkinsNode(withConnectionSecret()),
+			want: want{
+				o:         managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+				condition: xpv1.Available(),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e, s := newExternal(t)
+			for n, node := range tc.nodes {
+				s.AddNode(n, node)
+			}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			// The URL of the fake server is not known in advance.
+			ignoreURL := cmpopts.IgnoreMapEntries(func(k string, _ []byte) bool { return k == connectionKeyURL })
+			if diff := cmp.Diff(tc.want.o, got, ignoreURL); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if cr, ok := tc.mg.(*v1alpha1.JenkinsNode); ok && tc.want.condition.Type != "" {
+				if diff := cmp.Diff(tc.want.condition, cr.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+					t.Errorf("\n%s\ne.Observe(...): -want condition, +got condition:\n%s", tc.reason, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestObserveExecutors(t *testing.T) {
+	type executors struct {
+		Busy    int64
+		Idle    int64
+		Drained bool
+	}
+
+	cases := map[string]struct {
+		reason  string
+		busy    int64
+		offline bool
+		want    executors
+	}{
+		"Idle": {
+			reason: "An online node that is not running builds should not be drained.",
+			want:   executors{Idle: 2},
+		},
+		"Busy": {
+			reason: "The executors of an online node that are running builds should be busy.",
+			busy:   1,
+			want:   executors{Busy: 1, Idle: 1},
+		},
+		"Draining": {
+			reason:  "A temporarily offline node that is still running builds should not be drained.",
+			busy:    2,
+			offline: true,
+			want:    executors{Busy: 2},
+		},
+		"Drained": {
+			reason:  "A temporarily offline node that is not running builds should be drained.",
+			offline: true,
+			want:    executors{Idle: 2, Drained: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e, s := newExternal(t)
+			n := node()
+			n.NumExecutors, n.BusyExecutors = 2, tc.busy
+			n.TemporarilyOffline = tc.offline
+			s.AddNode("agent", n)
+
+			cr := jenkinsNode()
+			if _, err := e.Observe(context.Background(), cr); err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): unexpected error: %v", tc.reason, err)
+			}
+			o := cr.Status.AtProvider
+			got := executors{Busy: o.BusyExecutors, Idle: o.IdleExecutors, Drained: o.Drained}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want executors, +got executors:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		node   fake.Node
+		config []string
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		nodes  map[string]fake.Node
+		mg     resource.Managed
+		want   want
+	}{
+		"NotJenkinsNode": {
+			reason: "We should return an error if the managed resource is not a JenkinsNode.",
+			want:   want{err: errors.New(errNotJenkinsNode)},
+		},
+		"Created": {
+			reason: "A node should be created and configured from the JenkinsNode.",
+			mg:     jenkinsNode(withLabels("linux", "docker"), withMode(v1alpha1.JenkinsNodeModeExclusive)),
+			want: want{
+				node:   fake.Node{NumExecutors: 1, Description: "agent", RemoteFS: "/home/jenkins", Label: "linux docker", Offline: true, Launcher: inboundLauncherClass},
+				config: []string{"<mode>EXCLUSIVE</mode>"},
+			},
+		},
+		"CreatedOffline": {
+			reason: "A node should be taken offline if the JenkinsNode is offline.",
+			mg:     jenkinsNode(withOffline("maintenance")),
+			want: want{
+				node: fake.Node{NumExecutors: 1, Description: "agent", RemoteFS: "/home/jenkins", Offline: true, TemporarilyOffline: true, OfflineCause: "maintenance", Launcher: inboundLauncherClass},
+			},
+		},
+		"AlreadyExists": {
+			reason: "We should return an error if a node with the same name exists.",
+			nodes:  map[string]fake.Node{"agent": node()},
+			mg:     jenkinsNode(),
+			want: want{
+				node: node(),
+				err:  errors.Wrap(&clients.APIError{Method: http.MethodPost, Endpoint: "/computer/doCreateItem", StatusCode: http.StatusBadRequest, Reason: clients.ReasonUnknown}, errCreateNode),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e, s := newExternal(t)
+			for n, node := range tc.nodes {
+				s.AddNode(n, node)
+			}
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if _, ok := tc.mg.(*v1alpha1.JenkinsNode); !ok {
+				return
+			}
+			got, _ := s.GetNode("agent")
+			for _, c := range tc.want.config {
+				if !strings.Contains(got.Config, c) {
+					t.Errorf("\n%s\ne.Create(...): config.xml does not contain %s:\n%s", tc.reason, c, got.Config)
+				}
+			}
+			if diff := cmp.Diff(tc.want.node, got, cmpopts.IgnoreFields(fake.Node{}, "Config")); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want node, +got node:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type want struct {
+		node   fake.Node
+		config []string
+		err    error
+	}
+
+	withPlugin := node()
+	withPlugin.Config = nodeConfigWithPlugin
+	offline := node()
+	offline.TemporarilyOffline, offline.OfflineCause = true, "maintenance"
+
+	cases := map[string]struct {
+		reason string
+		nodes  map[string]fake.Node
+		mg     resource.Managed
+		want   want
+	}{
+		"NotJenkinsNode": {
+			reason: "We should return an error if the managed resource is not a JenkinsNode.",
+			want:   want{err: errors.New(errNotJenkinsNode)},
+		},
+		"ConfigUpdated": {
+			reason: "Managed settings should be updated, and unmanaged settings, including the omitted mode, preserved.",
+			nodes:  map[string]fake.Node{"agent": withPlugin},
+			mg:     jenkinsNode(withDescription("new")),
+			want: want{
+				node:   fake.Node{NumExecutors: 1, Description: "new", RemoteFS: "/home/jenkins", Launcher: inboundLauncherClass},
+				config: []string{"<plugin.Setting>kept</plugin.Setting>", "<mode>EXCLUSIVE</mode>"},
+			},
+		},
+		"TakenOffline": {
+			reason: "A node should be taken offline if the JenkinsNode is offline.",
+			nodes:  map[string]fake.Node{"agent": node()},
+			mg:     jenkinsNode(withOffline("maintenance")),
+			want: want{
+				node: fake.Node{NumExecutors: 1, Description: "agent", RemoteFS: "/home/jenkins", TemporarilyOffline: true, OfflineCause: "maintenance", Launcher: inboundLauncherClass},
+			},
+		},
+		"OfflineMessageChanged": {
+			reason: "The offline message of an offline node should be changed without bringing it online.",
+			nodes:  map[string]fake.Node{"agent": offline},
+			mg:     jenkinsNode(withOffline("upgrade")),
+			want: want{
+				node: fake.Node{NumExecutors: 1, Description: "agent", RemoteFS: "/home/jenkins", TemporarilyOffline: true, OfflineCause: "upgrade", Launcher: inboundLauncherClass},
+			},
+		},
+		"BroughtOnline": {
+			reason: "A node should be brought back online if the JenkinsNode is online.",
+			nodes:  map[string]fake.Node{"agent": offline},
+			mg:     jenkinsNode(),
+			want: want{
+				node: fake.Node{NumExecutors: 1, Description: "agent", RemoteFS: "/home/jenkins", Launcher: inboundLauncherClass},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e, s := newExternal(t)
+			for n, node := range tc.nodes {
+				s.AddNode(n, node)
+			}
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if _, ok := tc.mg.(*v1alpha1.JenkinsNode); !ok {
+				return
+			}
+			got, _ := s.GetNode("agent")
+			for _, c := range tc.want.config {
+				if !strings.Contains(got.Config, c) {
+					t.Errorf("\n%s\ne.Update(...): config.xml does not contain %s:\n%s", tc.reason, c, got.Config)
+				}
+			}
+			if diff := cmp.Diff(tc.want.node, got, cmpopts.IgnoreFields(fake.Node{}, "Config")); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want node, +got node:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		nodes  map[string]fake.Node
+		mg     resource.Managed
+		want   error
+	}{
+		"NotJenkinsNode": {
+			reason: "We should return an error if the managed resource is not a JenkinsNode.",
+			want:   errors.New(errNotJenkinsNode),
+		},
+		"Deleted": {
+			reason: "The node should be deleted.",
+			nodes:  map[string]fake.Node{"agent": node()},
+			mg:     jenkinsNode(),
+		},
+		"NotFound": {
+			reason: "A node that does not exist should be considered deleted.",
+			mg:     jenkinsNode(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e, s := newExternal(t)
+			for n, node := range tc.nodes {
+				s.AddNode(n, node)
+			}
+			err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if _, ok := s.GetNode("agent"); ok {
+				t.Errorf("\n%s\ne.Delete(...): node was not deleted", tc.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,404 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"context"
+	"encoding/xml"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/provider-jenkins/apis/dashboard/v1alpha1"
+	"github.com/crossplane/provider-jenkins/internal/clients"
+)
+
+// Classes of the Pipeline definitions and SCMs that can be generated.
+const (
+	cpsFlowDefinitionClass    = "org.jenkinsci.plugins.workflow.cps.CpsFlowDefinition"
+	cpsScmFlowDefinitionClass = "org.jenkinsci.plugins.workflow.cps.CpsScmFlowDefinition"
+	gitSCMClass               = "hudson.plugins.git.GitSCM"
+	logRotatorClass           = "hudson.tasks.LogRotator"
+)
+
+// parameterClasses maps Pipeline parameter types to the classes of their
+// definitions.
+var parameterClasses = map[v1alpha1.PipelineParameterType]string{
+	v1alpha1.PipelineParameterString:  "hudson.model.StringParameterDefinition",
+	v1alpha1.PipelineParameterText:    "hudson.model.TextParameterDefinition",
+	v1alpha1.PipelineParameterBoolean: "hudson.model.BooleanParameterDefinition",
+	v1alpha1.PipelineParameterChoice:  "hudson.model.ChoiceParameterDefinition",
+}
+
+// thresholds maps upstream thresholds to the results Jenkins serializes.
+var thresholds = map[v1alpha1.UpstreamThreshold]result{
+	v1alpha1.UpstreamThresholdSuccess:  {Name: "SUCCESS", Ordinal: 0, Color: "BLUE", CompleteBuild: true},
+	v1alpha1.UpstreamThresholdUnstable: {Name: "UNSTABLE", Ordinal: 1, Color: "YELLOW", CompleteBuild: true},
+	v1alpha1.UpstreamThresholdFailure:  {Name: "FAILURE", Ordinal: 2, Color: "RED", CompleteBuild: true},
+}
+
+// flowDefinition is the config.xml of a Pipeline job. It includes the
+// elements Jenkins writes when it saves a job, so that saving the job in the
+// UI does not cause a diff.
+type flowDefinition struct {
+	XMLName          xml.Name      `xml:"flow-definition"`
+	Actions          struct{}      `xml:"actions"`
+	Description      string        `xml:"description"`
+	KeepDependencies bool          `xml:"keepDependencies"`
+	Properties       jobProperties `xml:"properties"`
+	Definition       definition    `xml:"definition"`
+	Triggers         struct{}      `xml:"triggers"`
+	Disabled         bool          `xml:"disabled"`
+}
+
+type jobProperties struct {
+	BuildDiscarder *buildDiscarder   `xml:"jenkins.model.BuildDiscarderProperty>strategy,omitempty"`
+	Parameters     *parameters       `xml:"hudson.model.ParametersDefinitionProperty,omitempty"`
+	Triggers       *pipelineTriggers `xml:"org.jenkinsci.plugins.workflow.job.properties.PipelineTriggersJobProperty>triggers,omitempty"`
+}
+
+type buildDiscarder struct {
+	Class              string `xml:"class,attr"`
+	DaysToKeep         int64  `xml:"daysToKeep"`
+	NumToKeep          int64  `xml:"numToKeep"`
+	ArtifactDaysToKeep int64  `xml:"artifactDaysToKeep"`
+	ArtifactNumToKeep  int64  `xml:"artifactNumToKeep"`
+}
+
+type parameters struct {
+	// The element names of the definitions are set by their XMLName.
+	Definitions []parameter `xml:"parameterDefinitions>parameter"`
+}
+
+// parameter is a parameter definition. Its element name is the class of the
+// definition, and only the fields of that class are set.
+type parameter struct {
+	XMLName      xml.Name
+	Name         string   `xml:"name"`
+	Description  string   `xml:"description"`
+	DefaultValue *string  `xml:"defaultValue,omitempty"`
+	Trim         *bool    `xml:"trim,omitempty"`
+	Choices      *choices `xml:"choices,omitempty"`
+}
+
+type choices struct {
+	Class  string `xml:"class,attr"`
+	Values struct {
+		Class  string   `xml:"class,attr"`
+		Values []string `xml:"string"`
+	} `xml:"a"`
+}
+
+type pipelineTriggers struct {
+	Timer    *scmTrigger          `xml:"hudson.triggers.TimerTrigger,omitempty"`
+	SCM      *scmTrigger          `xml:"hudson.triggers.SCMTrigger,omitempty"`
+	Upstream *reverseBuildTrigger `xml:"jenkins.triggers.ReverseBuildTrigger,omitempty"`
+}
+
+type scmTrigger struct {
+	Spec                  string `xml:"spec"`
+	IgnorePostCommitHooks *bool  `xml:"ignorePostCommitHooks,omitempty"`
+}
+
+type reverseBuildTrigger struct {
+	Spec             string `xml:"spec"`
+	UpstreamProjects string `xml:"upstreamProjects"`
+	Threshold        result `xml:"threshold"`
+}
+
+type result struct {
+	Name          string `xml:"name"`
+	Ordinal       int    `xml:"ordinal"`
+	Color         string `xml:"color"`
+	CompleteBuild bool   `xml:"completeBuild"`
+}
+
+// definition is the flow definition of a Pipeline job. Only the fields of its
+// class are set.
+type definition struct {
+	Class string `xml:"class,attr"`
+
+	// Inline script.
+	Script  string `xml:"script,omitempty"`
+	Sandbox *bool  `xml:"sandbox,omitempty"`
+
+	// Script from SCM.
+	SCM         *gitSCM `xml:"scm,omitempty"`
+	ScriptPath  string  `xml:"scriptPath,omitempty"`
+	Lightweight *bool   `xml:"lightweight,omitempty"`
+}
+
+type gitSCM struct {
+	Class                             string             `xml:"class,attr"`
+	ConfigVersion                     int                `xml:"configVersion"`
+	UserRemoteConfigs                 []userRemoteConfig `xml:"userRemoteConfigs>hudson.plugins.git.UserRemoteConfig"`
+	Branches                          []string           `xml:"branches>hudson.plugins.git.BranchSpec>name"`
+	DoGenerateSubmoduleConfigurations bool               `xml:"doGenerateSubmoduleConfigurations"`
+	SubmoduleCfg                      struct {
+		Class string `xml:"class,attr"`
+	} `xml:"submoduleCfg"`
+	Extensions struct{} `xml:"extensions"`
+}
+
+type userRemoteConfig struct {
+	URL           string `xml:"url"`
+	CredentialsID string `xml:"credentialsId,omitempty"`
+}
+
+// desiredConfig returns the config.xml of the supplied job parameters.
+func desiredConfig(ctx context.Context, kube client.Client, p v1alpha1.JobParameters) (string, error) {
+	set := 0
+	for _, ok := range []bool{p.Config != "", p.ConfigFrom != nil, p.Pipeline != nil, p.Template != nil} {
+		if ok {
+			set++
+		}
+	}
+	if set != 1 {
+		return "", errors.New("exactly one of config, configFrom, pipeline or template must be specified")
+	}
+	switch {
+	case p.ConfigFrom != nil:
+		return readConfigSource(ctx, kube, *p.ConfigFrom)
+	case p.Pipeline != nil:
+		return generatePipelineConfig(*p.Pipeline)
+	case p.Template != nil:
+		return renderTemplate(ctx, kube, *p.Template, p.TemplateVariables)
+	}
+	return p.Config, nil
+}
+
+// readConfigSource reads the config.xml referenced by the supplied source.
+func readConfigSource(ctx context.Context, kube client.Client, s v1alpha1.JobConfigSource) (string, error) {
+	switch {
+	case s.ConfigMapKeyRef != nil && s.SecretKeyRef == nil:
+		return readConfigMapKey(ctx, kube, *s.ConfigMapKeyRef)
+	case s.SecretKeyRef != nil && s.ConfigMapKeyRef == nil:
+		ref := s.SecretKeyRef
+		sec := &corev1.Secret{}
+		if err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, sec); err != nil {
+			return "", errors.Wrapf(err, "cannot get Secret %s/%s", ref.Namespace, ref.Name)
+		}
+		if v, ok := sec.Data[ref.Key]; ok {
+			return string(v), nil
+		}
+		return "", errors.Errorf("key %s not found in Secret %s/%s", ref.Key, ref.Namespace, ref.Name)
+	}
+	return "", errors.New("exactly one of configMapKeyRef or secretKeyRef must be specified")
+}
+
+// readConfigMapKey reads the referenced key of a ConfigMap.
+func readConfigMapKey(ctx context.Context, kube client.Client, ref v1alpha1.ConfigMapKeySelector) (string, error) {
+	cm := &corev1.ConfigMap{}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, cm); err != nil {
+		return "", errors.Wrapf(err, "cannot get ConfigMap %s/%s", ref.Namespace, ref.Name)
+	}
+	if v, ok := cm.Data[ref.Key]; ok {
+		return v, nil
+	}
+	if v, ok := cm.BinaryData[ref.Key]; ok {
+		return string(v), nil
+	}
+	return "", errors.Errorf("key %s not found in ConfigMap %s/%s", ref.Key, ref.Namespace, ref.Name)
+}
+
+// templateFuncs are the functions available to job templates.
+var templateFuncs = template.FuncMap{
+	"xml": func(s string) (string, error) {
+		b := &strings.Builder{}
+		err := xml.EscapeText(b, []byte(s))
+		return b.String(), err
+	},
+}
+
+// readTemplate reads the text of the supplied template.
+func readTemplate(ctx context.Context, kube client.Client, t v1alpha1.JobTemplate) (string, error) {
+	switch {
+	case t.Inline != "" && t.ConfigMapKeyRef == nil:
+		return t.Inline, nil
+	case t.ConfigMapKeyRef != nil && t.Inline == "":
+		return readConfigMapKey(ctx, kube, *t.ConfigMapKeyRef)
+	}
+	return "", errors.New("exactly one of inline or configMapKeyRef template must be specified")
+}
+
+// renderTemplate renders the config.xml of a job from the supplied template
+// and variables. The rendered config.xml must be well-formed, so that an
+// invalid template never reaches Jenkins.
+func renderTemplate(ctx context.Context, kube client.Client, t v1alpha1.JobTemplate, vars map[string]string) (string, error) {
+	text, err := readTemplate(ctx, kube, t)
+	if err != nil {
+		return "", err
+	}
+	tmpl, err := template.New("config.xml").Option("missingkey=error").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return "", errors.Wrap(err, "cannot parse template")
+	}
+	if vars == nil {
+		vars = map[string]string{}
+	}
+	b := &strings.Builder{}
+	if err := tmpl.Execute(b, vars); err != nil {
+		return "", errors.Wrap(err, "cannot render template")
+	}
+	if err := clients.DecodeXML(b.String(), &struct{ XMLName xml.Name }{}); err != nil {
+		return "", errors.Wrap(err, "rendered template is not well-formed XML")
+	}
+	return b.String(), nil
+}
+
+// generatePipelineConfig produces the config.xml of the supplied Pipeline.
+func generatePipelineConfig(p v1alpha1.Pipeline) (string, error) {
+	def, err := generateDefinition(p)
+	if err != nil {
+		return "", err
+	}
+	cfg := flowDefinition{
+		Description: p.Description,
+		Definition:  def,
+		Disabled:    p.Disabled,
+	}
+	if d := p.BuildDiscarder; d != nil {
+		cfg.Properties.BuildDiscarder = &buildDiscarder{
+			Class:              logRotatorClass,
+			DaysToKeep:         valueOr(d.DaysToKeep, -1),
+			NumToKeep:          valueOr(d.NumToKeep, -1),
+			ArtifactDaysToKeep: valueOr(d.ArtifactDaysToKeep, -1),
+			ArtifactNumToKeep:  valueOr(d.ArtifactNumToKeep, -1),
+		}
+	}
+	if len(p.Parameters) > 0 {
+		cfg.Properties.Parameters = &parameters{}
+	}
+	for _, param := range p.Parameters {
+		pd, err := generateParameter(param)
+		if err != nil {
+			return "", err
+		}
+		cfg.Properties.Parameters.Definitions = append(cfg.Properties.Parameters.Definitions, pd)
+	}
+	if p.Triggers != nil {
+		cfg.Properties.Triggers = generateTriggers(*p.Triggers)
+	}
+	b, err := xml.MarshalIndent(cfg, "", "  ")
+	return string(b), errors.Wrap(err, "cannot encode config.xml")
+}
+
+// generateDefinition produces the flow definition of the supplied Pipeline.
+func generateDefinition(p v1alpha1.Pipeline) (definition, error) {
+	switch {
+	case p.Script != "" && p.Git == nil:
+		return definition{Class: cpsFlowDefinitionClass, Script: p.Script, Sandbox: boolOr(p.Sandbox, true)}, nil
+	case p.Git != nil && p.Script == "":
+		g := p.Git
+		scm := &gitSCM{
+			Class:             gitSCMClass,
+			ConfigVersion:     2,
+			UserRemoteConfigs: []userRemoteConfig{{URL: g.URL, CredentialsID: g.CredentialsID}},
+			Branches:          []string{stringOr(g.Branch, "*/main")},
+		}
+		scm.SubmoduleCfg.Class = "empty-list"
+		return definition{
+			Class:       cpsScmFlowDefinitionClass,
+			SCM:         scm,
+			ScriptPath:  stringOr(g.ScriptPath, "Jenkinsfile"),
+			Lightweight: boolOr(g.Lightweight, true),
+		}, nil
+	}
+	return definition{}, errors.New("exactly one of script or git must be specified")
+}
+
+// generateParameter produces the definition of the supplied parameter.
+func generateParameter(p v1alpha1.PipelineParameter) (parameter, error) {
+	t := p.Type
+	if t == "" {
+		t = v1alpha1.PipelineParameterString
+	}
+	class, ok := parameterClasses[t]
+	if !ok {
+		return parameter{}, errors.Errorf("unknown type %q of parameter %s", t, p.Name)
+	}
+	pd := parameter{XMLName: xml.Name{Local: class}, Name: p.Name, Description: p.Description}
+	switch t {
+	case v1alpha1.PipelineParameterString, v1alpha1.PipelineParameterText:
+		pd.DefaultValue = &p.DefaultValue
+		pd.Trim = new(bool)
+	case v1alpha1.PipelineParameterBoolean:
+		v, err := strconv.ParseBool(stringOr(p.DefaultValue, "false"))
+		if err != nil {
+			return parameter{}, errors.Errorf("default value of boolean parameter %s must be true or false", p.Name)
+		}
+		s := strconv.FormatBool(v)
+		pd.DefaultValue = &s
+	case v1alpha1.PipelineParameterChoice:
+		if len(p.Choices) == 0 {
+			return parameter{}, errors.Errorf("choice parameter %s has no choices", p.Name)
+		}
+		pd.Choices = &choices{Class: "java.util.Arrays$ArrayList"}
+		pd.Choices.Values.Class = "string-array"
+		pd.Choices.Values.Values = p.Choices
+	}
+	return pd, nil
+}
+
+// generateTriggers produces the triggers property of the supplied triggers,
+// or nil if there are none.
+func generateTriggers(t v1alpha1.PipelineTriggers) *pipelineTriggers {
+	pt := &pipelineTriggers{}
+	if t.Cron != "" {
+		pt.Timer = &scmTrigger{Spec: t.Cron}
+	}
+	if t.PollSCM != "" {
+		pt.SCM = &scmTrigger{Spec: t.PollSCM, IgnorePostCommitHooks: new(bool)}
+	}
+	if u := t.Upstream; u != nil && len(u.Projects) > 0 {
+		threshold, ok := thresholds[u.Threshold]
+		if !ok {
+			threshold = thresholds[v1alpha1.UpstreamThresholdSuccess]
+		}
+		pt.Upstream = &reverseBuildTrigger{UpstreamProjects: strings.Join(u.Projects, ","), Threshold: threshold}
+	}
+	if pt.Timer == nil && pt.SCM == nil && pt.Upstream == nil {
+		return nil
+	}
+	return pt
+}
+
+func valueOr(v *int64, def int64) int64 {
+	if v == nil {
+		return def
+	}
+	return *v
+}
+
+func boolOr(v *bool, def bool) *bool {
+	if v == nil {
+		return &def
+	}
+	return v
+}
+
+func stringOr(v, def string) string {
+	if v == "" {
+		return def
+	}
+	return v
+}
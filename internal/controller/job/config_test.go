@@ -0,0 +1,306 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-jenkins/apis/dashboard/v1alpha1"
+	"github.com/crossplane/provider-jenkins/internal/clients"
+)
+
+// flowDefinitionXML returns the config.xml of a Pipeline job with the supplied
+// properties and definition.
+func flowDefinitionXML(properties, definition string) string {
+	return `<flow-definition>
+  <actions></actions>
+  <description></description>
+  <keepDependencies>false</keepDependencies>
+  <properties>` + properties + `</properties>
+  ` + definition + `
+  <triggers></triggers>
+  <disabled>false</disabled>
+</flow-definition>`
+}
+
+const inlineDefinition = `<definition class="org.jenkinsci.plugins.workflow.cps.CpsFlowDefinition"><script>echo 'hi'</script><sandbox>true</sandbox></definition>`
+
+func TestGeneratePipelineConfig(t *testing.T) {
+	type want struct {
+		config string
+		err    error
+	}
+
+	days := int64(7)
+	sandbox, lightweight := false, false
+
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.Pipeline
+		want   want
+	}{
+		"InlineScript": {
+			reason: "An inline script should run in the sandbox by default.",
+			p:      v1alpha1.Pipeline{Script: "echo 'hi'"},
+			want:   want{config: flowDefinitionXML("", inlineDefinition)},
+		},
+		"InlineScriptWithoutSandbox": {
+			reason: "The sandbox should be disabled if requested.",
+			p:      v1alpha1.Pipeline{Script: "echo 'hi'", Sandbox: &sandbox},
+			want: want{config: flowDefinitionXML("",
+				`<definition class="org.jenkinsci.plugins.workflow.cps.CpsFlowDefinition"><script>echo 'hi'</script><sandbox>false</sandbox></definition>`)},
+		},
+		"GitDefaults": {
+			reason: "A Git source should default to the main branch, the Jenkinsfile and a lightweight checkout.",
+			p:      v1alpha1.Pipeline{Git: &v1alpha1.PipelineGitSource{URL: "https://example.org/repo.git"}},
+			want: want{config: flowDefinitionXML("", `<definition class="org.jenkinsci.plugins.workflow.cps.CpsScmFlowDefinition">
+    <scm class="hudson.plugins.git.GitSCM">
+      <configVersion>2</configVersion>
+      <userRemoteConfigs><hudson.plugins.git.UserRemoteConfig><url>https://example.org/repo.git</url></hudson.plugins.git.UserRemoteConfig></userRemoteConfigs>
+      <branches><hudson.plugins.git.BranchSpec><name>*/main</name></hudson.plugins.git.BranchSpec></branches>
+      <doGenerateSubmoduleConfigurations>false</doGenerateSubmoduleConfigurations>
+      <submoduleCfg class="empty-list"></submoduleCfg>
+      <extensions></extensions>
+    </scm>
+    <scriptPath>Jenkinsfile</scriptPath>
+    <lightweight>true</lightweight>
+  </definition>`)},
+		},
+		"Git": {
+			reason: "The settings of a Git source should be written.",
+			p: v1alpha1.Pipeline{Git: &v1alpha1.PipelineGitSource{
+				URL:           "https://example.org/repo.git",
+				Branch:        "*/release",
+				CredentialsID: "git",
+				ScriptPath:    "ci/Jenkinsfile",
+				Lightweight:   &lightweight,
+			}},
+			want: want{config: flowDefinitionXML("", `<definition class="org.jenkinsci.plugins.workflow.cps.CpsScmFlowDefinition">
+    <scm class="hudson.plugins.git.GitSCM">
+      <configVersion>2</configVersion>
+      <userRemoteConfigs><hudson.plugins.git.UserRemoteConfig><url>https://example.org/repo.git</url><credentialsId>git</credentialsId></hudson.plugins.git.UserRemoteConfig></userRemoteConfigs>
+      <branches><hudson.plugins.git.BranchSpec><name>*/release</name></hudson.plugins.git.BranchSpec></branches>
+      <doGenerateSubmoduleConfigurations>false</doGenerateSubmoduleConfigurations>
+      <submoduleCfg class="empty-list"></submoduleCfg>
+      <extensions></extensions>
+    </scm>
+    <scriptPath>ci/Jenkinsfile</scriptPath>
+    <lightweight>false</lightweight>
+  </definition>`)},
+		},
+		"BuildDiscarder": {
+			reason: "Omitted build discarder limits should be written as -1.",
+			p:      v1alpha1.Pipeline{Script: "echo 'hi'", BuildDiscarder: &v1alpha1.BuildDiscarder{DaysToKeep: &days}},
+			want: want{config: flowDefinitionXML(`<jenkins.model.BuildDiscarderProperty><strategy class="hudson.tasks.LogRotator">
+      <daysToKeep>7</daysToKeep><numToKeep>-1</numToKeep><artifactDaysToKeep>-1</artifactDaysToKeep><artifactNumToKeep>-1</artifactNumToKeep>
+    </strategy></jenkins.model.BuildDiscarderProperty>`, inlineDefinition)},
+		},
+		"Parameters": {
+			reason: "Each parameter should be written as a definition of its type.",
+			p: v1alpha1.Pipeline{Script: "echo 'hi'", Parameters: []v1alpha1.PipelineParameter{
+				{Name: "TARGET", DefaultValue: "prod"},
+				{Name: "DRY_RUN", Type: v1alpha1.PipelineParameterBoolean},
+				{Name: "REGION", Type: v1alpha1.PipelineParameterChoice, Choices: []string{"eu", "us"}},
+			}},
+			want: want{config: flowDefinitionXML(`<hudson.model.ParametersDefinitionProperty><parameterDefinitions>
+      <hudson.model.StringParameterDefinition><name>TARGET</name><description></description><defaultValue>prod</defaultValue><trim>false</trim></hudson.model.StringParameterDefinition>
+      <hudson.model.BooleanParameterDefinition><name>DRY_RUN</name><description></description><defaultValue>false</defaultValue></hudson.model.BooleanParameterDefinition>
+      <hudson.model.ChoiceParameterDefinition><name>REGION</name><description></description>
+        <choices class="java.util.Arrays$ArrayList"><a class="string-array"><string>eu</string><string>us</string></a></choices>
+      </hudson.model.ChoiceParameterDefinition>
+    </parameterDefinitions></hudson.model.ParametersDefinitionProperty>`, inlineDefinition)},
+		},
+		"Triggers": {
+			reason: "Triggers should be written, and the upstream threshold should default to success.",
+			p: v1alpha1.Pipeline{Script: "echo 'hi'", Triggers: &v1alpha1.PipelineTriggers{
+				Cron:     "H 2 * * *",
+				PollSCM:  "H/5 * * * *",
+				Upstream: &v1alpha1.UpstreamTrigger{Projects: []string{"a", "folder/b"}},
+			}},
+			want: want{config: flowDefinitionXML(`<org.jenkinsci.plugins.workflow.job.properties.PipelineTriggersJobProperty><triggers>
+      <hudson.triggers.TimerTrigger><spec>H 2 * * *</spec></hudson.triggers.TimerTrigger>
+      <hudson.triggers.SCMTrigger><spec>H/5 * * * *</spec><ignorePostCommitHooks>false</ignorePostCommitHooks></hudson.triggers.SCMTrigger>
+      <jenkins.triggers.ReverseBuildTrigger><spec></spec><upstreamProjects>a,folder/b</upstreamProjects>
+        <threshold><name>SUCCESS</name><ordinal>0</ordinal><color>BLUE</color><completeBuild>true</completeBuild></threshold>
+      </jenkins.triggers.ReverseBuildTrigger>
+    </triggers></org.jenkinsci.plugins.workflow.job.properties.PipelineTriggersJobProperty>`, inlineDefinition)},
+		},
+		"EmptyTriggers": {
+			reason: "Triggers without any trigger should not be written.",
+			p:      v1alpha1.Pipeline{Script: "echo 'hi'", Triggers: &v1alpha1.PipelineTriggers{}},
+			want:   want{config: flowDefinitionXML("", inlineDefinition)},
+		},
+		"NoDefinition": {
+			reason: "We should return an error if neither a script nor Git is specified.",
+			p:      v1alpha1.Pipeline{},
+			want:   want{err: errors.New("exactly one of script or git must be specified")},
+		},
+		"BothDefinitions": {
+			reason: "We should return an error if both a script and Git are specified.",
+			p:      v1alpha1.Pipeline{Script: "echo 'hi'", Git: &v1alpha1.PipelineGitSource{URL: "https://example.org/repo.git"}},
+			want:   want{err: errors.New("exactly one of script or git must be specified")},
+		},
+		"InvalidBoolean": {
+			reason: "We should return an error if the default value of a boolean parameter is invalid.",
+			p:      v1alpha1.Pipeline{Script: "echo 'hi'", Parameters: []v1alpha1.PipelineParameter{{Name: "DRY_RUN", Type: v1alpha1.PipelineParameterBoolean, DefaultValue: "maybe"}}},
+			want:   want{err: errors.New("default value of boolean parameter DRY_RUN must be true or false")},
+		},
+		"NoChoices": {
+			reason: "We should return an error if a choice parameter has no choices.",
+			p:      v1alpha1.Pipeline{Script: "echo 'hi'", Parameters: []v1alpha1.PipelineParameter{{Name: "REGION", Type: v1alpha1.PipelineParameterChoice}}},
+			want:   want{err: errors.New("choice parameter REGION has no choices")},
+		},
+		"UnknownType": {
+			reason: "We should return an error if the type of a parameter is unknown.",
+			p:      v1alpha1.Pipeline{Script: "echo 'hi'", Parameters: []v1alpha1.PipelineParameter{{Name: "FILE", Type: "File"}}},
+			want:   want{err: errors.New(`unknown type "File" of parameter FILE`)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := generatePipelineConfig(tc.p)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ngeneratePipelineConfig(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if tc.want.err != nil {
+				return
+			}
+			if !clients.EqualXML(tc.want.config, got, false) {
+				t.Errorf("\n%s\ngeneratePipelineConfig(...): want config.xml:\n%s\ngot config.xml:\n%s", tc.reason, tc.want.config, got)
+			}
+		})
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	type args struct {
+		kube client.Client
+		t    v1alpha1.JobTemplate
+		vars map[string]string
+	}
+	type want struct {
+		config string
+		err    error
+	}
+
+	errBoom := errors.New("boom")
+	ref := &v1alpha1.ConfigMapKeySelector{Name: "templates", Namespace: "default", Key: "config.xml"}
+	configMap := func(data map[string]string) client.Client {
+		return &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			obj.(*corev1.ConfigMap).Data = data
+			return nil
+		})}
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Inline": {
+			reason: "An inline template should be rendered with the variables.",
+			args: args{
+				t:    v1alpha1.JobTemplate{Inline: `<project><description>{{ .description }}</description></project>`},
+				vars: map[string]string{"description": "deploy"},
+			},
+			want: want{config: `<project><description>deploy</description></project>`},
+		},
+		"Escaped": {
+			reason: "The xml function should escape a value for use in XML.",
+			args: args{
+				t:    v1alpha1.JobTemplate{Inline: `<project><description>{{ .description | xml }}</description></project>`},
+				vars: map[string]string{"description": "<a> & <b>"},
+			},
+			want: want{config: `<project><description>&lt;a&gt; &amp; &lt;b&gt;</description></project>`},
+		},
+		"ConfigMap": {
+			reason: "A template should be read from the referenced key of a ConfigMap.",
+			args: args{
+				kube: configMap(map[string]string{"config.xml": `<project><description>{{ .description }}</description></project>`}),
+				t:    v1alpha1.JobTemplate{ConfigMapKeyRef: ref},
+				vars: map[string]string{"description": "deploy"},
+			},
+			want: want{config: `<project><description>deploy</description></project>`},
+		},
+		"MissingKey": {
+			reason: "We should return an error if the template references a variable that is not set.",
+			args: args{
+				t: v1alpha1.JobTemplate{Inline: `<project><description>{{ .description }}</description></project>`},
+			},
+			want: want{err: errors.Wrap(errors.New(`template: config.xml:1:25: executing "config.xml" at <.description>: map has no entry for key "description"`), "cannot render template")},
+		},
+		"InvalidTemplate": {
+			reason: "We should return an error if the template cannot be parsed.",
+			args: args{
+				t: v1alpha1.JobTemplate{Inline: `<project>{{ .description </project>`},
+			},
+			want: want{err: errors.Wrap(errors.New(`template: config.xml:1: unexpected "<" in operand`), "cannot parse template")},
+		},
+		"NotWellFormed": {
+			reason: "We should return an error if the rendered template is not well-formed XML.",
+			args: args{
+				t:    v1alpha1.JobTemplate{Inline: `<project><description>{{ .description }}</description></project>`},
+				vars: map[string]string{"description": "a & b"},
+			},
+			want: want{err: errors.Wrap(errors.Wrap(errors.New("XML syntax error on line 1: invalid character entity & (no semicolon)"), "cannot decode config.xml"), "rendered template is not well-formed XML")},
+		},
+		"BothSources": {
+			reason: "We should return an error if both an inline and a ConfigMap template are specified.",
+			args: args{
+				t: v1alpha1.JobTemplate{Inline: "<project/>", ConfigMapKeyRef: ref},
+			},
+			want: want{err: errors.New("exactly one of inline or configMapKeyRef template must be specified")},
+		},
+		"ConfigMapKeyNotFound": {
+			reason: "We should return an error if the ConfigMap lacks the referenced key.",
+			args: args{
+				kube: configMap(map[string]string{}),
+				t:    v1alpha1.JobTemplate{ConfigMapKeyRef: ref},
+			},
+			want: want{err: errors.New("key config.xml not found in ConfigMap default/templates")},
+		},
+		"GetConfigMapError": {
+			reason: "We should return an error if the ConfigMap cannot be read.",
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+				t:    v1alpha1.JobTemplate{ConfigMapKeyRef: ref},
+			},
+			want: want{err: errors.Wrap(errBoom, "cannot get ConfigMap default/templates")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := renderTemplate(context.Background(), tc.args.kube, tc.args.t, tc.args.vars)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nrenderTemplate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.config, got); diff != "" {
+				t.Errorf("\n%s\nrenderTemplate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
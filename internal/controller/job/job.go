@@ -18,14 +18,17 @@ package job
 
 import (
 	"context"
-	"fmt"
+	"time"
 
-	jenkins "github.com/bndr/gojenkins"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/connection"
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
@@ -36,13 +39,21 @@ import (
 	"github.com/crossplane/provider-jenkins/apis/dashboard/v1alpha1"
 	apisv1alpha1 "github.com/crossplane/provider-jenkins/apis/v1alpha1"
 	"github.com/crossplane/provider-jenkins/internal/controller/features"
+	"github.com/crossplane/provider-jenkins/internal/controller/item"
 
 	clients "github.com/crossplane/provider-jenkins/internal/clients"
 )
 
 const (
-	errNotJob       = "managed resource is not a Job custom resource"
-	errTrackPCUsage = "cannot track ProviderConfig usage"
+	errNotJob         = "managed resource is not a Job custom resource"
+	errNewClient      = "cannot create Jenkins client"
+	errTrackPCUsage   = "cannot track ProviderConfig usage"
+	errGetJob         = "cannot get Jenkins job"
+	errGetJobConfig   = "cannot get Jenkins job config"
+	errGenerateConfig = "cannot generate Jenkins job config"
+	errCreateJob      = "cannot create Jenkins job"
+	errUpdateJob      = "cannot update Jenkins job config"
+	errDeleteJob      = "cannot delete Jenkins job"
 )
 
 // Setup adds a controller that reconciles Job managed resources.
@@ -54,36 +65,51 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
 	}
 
+	cc := clients.NewClientCache(clients.NewClient)
+	if err := cc.EvictOnDelete(context.Background(), mgr.GetCache()); err != nil {
+		return err
+	}
+
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.JobGroupVersionKind),
 		managed.WithExternalConnecter(&connector{
-			kube:         mgr.GetClient(),
-			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
-			newServiceFn: clients.NewClient}),
+			kube:    mgr.GetClient(),
+			usage:   resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			clients: cc}),
+		// The external name is the full name of the job, which is set by
+		// Create rather than defaulted to the name of the managed resource.
+		managed.WithInitializers(),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
 		managed.WithConnectionPublishers(cps...))
 
+	if err := setupConfigSourceIndex(mgr); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
 		For(&v1alpha1.Job{}).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, enqueueJobsFor(mgr.GetClient(), kindConfigMap)).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, enqueueJobsFor(mgr.GetClient(), kindSecret)).
 		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
 }
 
 // A connector is expected to produce an ExternalClient when its Connect method
 // is called.
 type connector struct {
-	kube         client.Client
-	usage        resource.Tracker
-	newServiceFn func(c clients.Config) *jenkins.Jenkins
+	kube    client.Client
+	usage   resource.Tracker
+	clients *clients.ClientCache
 }
 
 // Connect typically produces an ExternalClient by:
 // 1. Tracking that the managed resource is using a ProviderConfig.
 // 2. Getting the managed resource's ProviderConfig.
 // 3. Getting the credentials specified by the ProviderConfig.
-// 4. Using the credentials to form a client.
+// 4. Using the credentials to form a client, or reusing a cached client if
+// neither the ProviderConfig nor its credentials changed.
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
 	cr, ok := mg.(*v1alpha1.Job)
 	if !ok {
@@ -94,12 +120,11 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errTrackPCUsage)
 	}
 
-	cfg, err := clients.GetConfig(ctx, c.kube, cr)
+	svc, err := c.clients.Get(ctx, c.kube, cr)
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrap(err, errNewClient)
 	}
-	fmt.Println("\n\nConnect Completed")
-	return &external{kube: c.kube, service: c.newServiceFn(*cfg)}, nil
+	return &external{kube: c.kube, service: svc}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
@@ -108,25 +133,46 @@ type external struct {
 	// A 'client' used to connect to the external resource API. In practice this
 	// would be something like an AWS SDK client.
 	kube    client.Client
-	service *jenkins.Jenkins
+	service clients.Client
 }
 
-func getJobByName(ctx context.Context, name string, parent string, c *external) (*jenkins.Job, error) {
-	var job *jenkins.Job
-	var err error
+// fullName returns the full name of the job, e.g. "folder/sub/job".
+func fullName(p v1alpha1.JobParameters) string {
+	return item.FullName(p.Parent, p.Name)
+}
+
+// generateObservation produces a JobObservation from the state of a job as
+// reported by Jenkins.
+func generateObservation(job *clients.Job) v1alpha1.JobObservation {
+	o := v1alpha1.JobObservation{
+		Name:                job.Name,
+		FullName:            job.FullName,
+		URL:                 job.URL,
+		Buildable:           job.Buildable,
+		Disabled:            job.Disabled,
+		Color:               job.Color,
+		LastBuild:           generateBuildObservation(job.LastBuild),
+		LastSuccessfulBuild: generateBuildObservation(job.LastSuccessfulBuild),
+		LastFailedBuild:     generateBuildObservation(job.LastFailedBuild),
+	}
+	for i := range job.HealthReport {
+		if o.HealthScore == nil || job.HealthReport[i].Score < *o.HealthScore {
+			o.HealthScore = &job.HealthReport[i].Score
+		}
+	}
+	return o
+}
 
-	if parent == "" {
-		job, err = c.service.GetJob(ctx, name)
-	} else {
-		job, err = c.service.GetJob(ctx, name, parent)
+func generateBuildObservation(b *clients.Build) *v1alpha1.BuildObservation {
+	if b == nil {
+		return nil
 	}
-	if job != nil {
-		fmt.Println("\nGet Job Found -> " + job.GetName())
-		fmt.Println(ctx)
-	} else {
-		fmt.Println("\nJob Cant Found -> " + err.Error())
+	o := &v1alpha1.BuildObservation{Number: b.Number, Result: b.Result}
+	if b.Timestamp > 0 {
+		t := metav1.NewTime(time.UnixMilli(b.Timestamp))
+		o.Timestamp = &t
 	}
-	return job, err
+	return o
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -134,38 +180,39 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotJob)
 	}
-	// These fmt statements should be removed in the real implementation.
-	fmt.Printf("Observing: %+v", cr)
-
-	externalName := meta.GetExternalName(cr) // What is this
+	// The external name is the full name of the job in Jenkins. It is set by
+	// Create, or by the user to import an existing job.
+	externalName := meta.GetExternalName(cr)
 	if externalName == "" {
 		return managed.ExternalObservation{ResourceExists: false}, nil // trigger Create
 	}
 
-	forProvider := &cr.Spec.ForProvider
-	job, err := getJobByName(ctx, forProvider.Name, forProvider.Parent, c)
-
-	switch {
-	case err != nil && err.Error() == "404":
-		fmt.Println("404 Job Cant Found: " + forProvider.Name)
-		return managed.ExternalObservation{ResourceExists: false}, nil // trigger Create
-
-	case err != nil:
-		fmt.Println("\nGet Job Error: " + err.Error())
-
-	default:
-		jobConfig, err := job.GetConfig(ctx)
-		switch {
-		case err != nil:
-			fmt.Println("\nGet Config Error: " + err.Error())
+	job, err := c.service.GetJob(ctx, externalName)
+	if err != nil {
+		if clients.IsNotFound(err) {
+			return managed.ExternalObservation{ResourceExists: false}, nil // trigger Create
+		}
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetJob)
+	}
+	cr.Status.AtProvider = generateObservation(job)
+	cr.SetConditions(xpv1.Available())
 
-		case jobConfig != forProvider.Config:
-			fmt.Println("\nJob Config Need To Be Updated: " + job.GetName() + "\n")
-			return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}, nil // trigger Update
+	forProvider := &cr.Spec.ForProvider
+	if externalName != fullName(*forProvider) {
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}, nil // trigger Update
+	}
 
-		default:
-			fmt.Print("\nJob Exist: " + job.GetName() + " Everything OK\n\n")
-		}
+	desired, err := desiredConfig(ctx, c.kube, *forProvider)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGenerateConfig)
+	}
+	jobConfig, err := c.service.GetJobConfig(ctx, externalName)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetJobConfig)
+	}
+	ignorePlugins := forProvider.IgnorePluginVersions == nil || *forProvider.IgnorePluginVersions
+	if !clients.EqualXML(jobConfig, desired, ignorePlugins) {
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}, nil // trigger Update
 	}
 
 	return managed.ExternalObservation{
@@ -191,25 +238,15 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errNotJob)
 	}
 
-	fmt.Printf("Creating: %+v", cr)
-
 	forProvider := &cr.Spec.ForProvider
-	var job *jenkins.Job
-	var err error
-
-	if forProvider.Parent == "" {
-		fmt.Println("Creating: " + forProvider.Name + " Config: " + forProvider.Config + "\n\n")
-		job, err = c.service.CreateJob(ctx, forProvider.Config, forProvider.Name)
-	} else {
-		fmt.Println("\nParent: ", forProvider.Parent, " -> Creating: ", forProvider.Name+" Parent: "+forProvider.Parent+" Config: "+forProvider.Config+"\n\n")
-		job, err = c.service.CreateJobInFolder(ctx, forProvider.Config, forProvider.Name, forProvider.Parent)
+	config, err := desiredConfig(ctx, c.kube, *forProvider)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errGenerateConfig)
 	}
-
-	if err != nil || job == nil {
-		fmt.Println("\nCreating Job Error " + err.Error())
-	} else {
-		fmt.Println("\nJob Successfully Created:  ", job.GetName())
+	if err := c.service.CreateJob(ctx, fullName(*forProvider), config); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateJob)
 	}
+	meta.SetExternalName(cr, fullName(*forProvider))
 
 	return managed.ExternalCreation{
 		// Optionally return any details that may be required to connect to the
@@ -224,21 +261,16 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errNotJob)
 	}
 
-	fmt.Printf("Updating: %+v", cr)
-
 	forProvider := &cr.Spec.ForProvider
-	job, err := getJobByName(ctx, forProvider.Name, forProvider.Parent, c)
+	config, err := desiredConfig(ctx, c.kube, *forProvider)
 	if err != nil {
-		if err.Error() == "404" {
-			fmt.Println("Update Error -> Job Cant Found " + forProvider.Name)
-		} else {
-			fmt.Println("Update Error -> " + err.Error())
-		}
-	} else {
-		err := job.UpdateConfig(ctx, forProvider.Config)
-		if err != nil {
-			fmt.Println("Update Config Error -> " + err.Error())
-		}
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGenerateConfig)
+	}
+	if err := item.Move(ctx, c.kube, c.service, cr, forProvider.Parent, forProvider.Name); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if err := c.service.UpdateJobConfig(ctx, fullName(*forProvider), config); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateJob)
 	}
 
 	return managed.ExternalUpdate{
@@ -254,24 +286,14 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 		return errors.New(errNotJob)
 	}
 
-	fmt.Printf("\nDeleting: %+v", cr)
-
-	forProvider := &cr.Spec.ForProvider
-	job, err := getJobByName(ctx, forProvider.Name, forProvider.Parent, c)
-
-	if err != nil {
-		if err.Error() == "404" {
-			fmt.Println("Delete Error -> Job Cant Found " + forProvider.Name)
-		} else {
-			fmt.Println("Delete Error -> " + err.Error())
-		}
-	} else {
-		isdeleted, err := job.Delete(ctx)
-		if err != nil || !isdeleted {
-			fmt.Println("\nError Job Can't Deleted: " + err.Error())
-		} else {
-			fmt.Println("\nDelete Completed")
-		}
+	// A Job without an external name was never created in Jenkins.
+	externalName := meta.GetExternalName(cr)
+	if externalName == "" {
+		return nil
 	}
-	return nil
+
+	// Returning an error keeps the finalizer in place, so the job is deleted
+	// again on the next reconcile until Jenkins confirms it is gone.
+	err := c.service.DeleteJob(ctx, externalName)
+	return errors.Wrap(resource.Ignore(clients.IsNotFound, err), errDeleteJob)
 }
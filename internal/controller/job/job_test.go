@@ -0,0 +1,416 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-jenkins/apis/dashboard/v1alpha1"
+	"github.com/crossplane/provider-jenkins/internal/clients"
+	"github.com/crossplane/provider-jenkins/internal/clients/fake"
+)
+
+const (
+	jobConfig        = `<project><description>job</description></project>`
+	changedJobConfig = `<project><description>changed</description></project>`
+)
+
+type jobModifier func(*v1alpha1.Job)
+
+func withExternalName(n string) jobModifier {
+	return func(j *v1alpha1.Job) { meta.SetExternalName(j, n) }
+}
+
+func withParent(p string) jobModifier {
+	return func(j *v1alpha1.Job) { j.Spec.ForProvider.Parent = p }
+}
+
+func withConfig(c string) jobModifier {
+	return func(j *v1alpha1.Job) { j.Spec.ForProvider.Config = c }
+}
+
+func withPipeline(p *v1alpha1.Pipeline) jobModifier {
+	return func(j *v1alpha1.Job) {
+		j.Spec.ForProvider.Config = ""
+		j.Spec.ForProvider.Pipeline = p
+	}
+}
+
+func withTemplate(t *v1alpha1.JobTemplate, vars map[string]string) jobModifier {
+	return func(j *v1alpha1.Job) {
+		j.Spec.ForProvider.Config = ""
+		j.Spec.ForProvider.Template = t
+		j.Spec.ForProvider.TemplateVariables = vars
+	}
+}
+
+func job(m ...jobModifier) *v1alpha1.Job {
+	cr := &v1alpha1.Job{Spec: v1alpha1.JobSpec{ForProvider: v1alpha1.JobParameters{Name: "job", Config: jobConfig}}}
+	cr.SetName("job")
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+// newExternal returns an external client connected to a new fake Jenkins
+// server.
+func newExternal(t *testing.T, kube client.Client) (*external, *fake.Server) {
+	t.Helper()
+	s := fake.NewServer()
+	t.Cleanup(s.Close)
+	svc, err := clients.NewClient(s.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &external{kube: kube, service: svc}, s
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		o         managed.ExternalObservation
+		available bool
+		err       error
+	}
+
+	cases := map[string]struct {
+		reason string
+		jobs   map[string]string
+		mg     resource.Managed
+		want   want
+	}{
+		"NotJob": {
+			reason: "We should return an error if the managed resource is not a Job.",
+			want:   want{err: errors.New(errNotJob)},
+		},
+		"NoExternalName": {
+			reason: "A Job without an external name should be created.",
+			mg:     job(),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"NotFound": {
+			reason: "A Job whose job does not exist should be created.",
+			mg:     job(withExternalName("job")),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"UpToDate": {
+			reason: "A Job whose config.xml matches should be up to date and available.",
+			jobs:   map[string]string{"job": jobConfig},
+			mg:     job(withExternalName("job")),
+			want: want{
+				o:         managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+				available: true,
+			},
+		},
+		"IgnorePluginVersions": {
+			reason: "Plugin versions should be ignored by default.",
+			jobs:   map[string]string{"job": `<project plugin="job@2"><description>job</description></project>`},
+			mg:     job(withExternalName("job"), withConfig(`<project plugin="job@1"><description>job</description></project>`)),
+			want: want{
+				o:         managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+				available: true,
+			},
+		},
+		"ConfigChanged": {
+			reason: "A Job whose config.xml differs should be updated.",
+			jobs:   map[string]string{"job": changedJobConfig},
+			mg:     job(withExternalName("job")),
+			want: want{
+				o:         managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				available: true,
+			},
+		},
+		"Moved": {
+			reason: "A Job whose parent changed should be updated.",
+			jobs:   map[string]string{"job": jobConfig},
+			mg:     job(withExternalName("job"), withParent("folder")),
+			want: want{
+				o:         managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				available: true,
+			},
+		},
+		"PipelineUpToDate": {
+			reason: "A Job whose generated Pipeline config.xml matches should be up to date.",
+			jobs:   map[string]string{"job": mustPipelineConfig(t, v1alpha1.Pipeline{Script: "echo 'hi'"})},
+			mg:     job(withExternalName("job"), withPipeline(&v1alpha1.Pipeline{Script: "echo 'hi'"})),
+			want: want{
+				o:         managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+				available: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e, s := newExternal(t, &test.MockClient{})
+			for n, c := range tc.jobs {
+				s.AddJob(n, c)
+			}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if cr, ok := tc.mg.(*v1alpha1.Job); ok {
+				available := cr.GetCondition(xpv1.TypeReady).Equal(xpv1.Available())
+				if diff := cmp.Diff(tc.want.available, available); diff != "" {
+					t.Errorf("\n%s\ne.Observe(...): -want available, +got available:\n%s", tc.reason, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		externalName string
+		config       string
+		err          error
+	}
+
+	template := `<project><description>{{ .description | xml }}</description></project>`
+
+	cases := map[string]struct {
+		reason  string
+		folders []string
+		jobs    map[string]string
+		kube    client.Client
+		mg      resource.Managed
+		want    want
+	}{
+		"NotJob": {
+			reason: "We should return an error if the managed resource is not a Job.",
+			want:   want{err: errors.New(errNotJob)},
+		},
+		"Created": {
+			reason: "A job should be created from the config.xml of the Job.",
+			mg:     job(),
+			want:   want{externalName: "job", config: jobConfig},
+		},
+		"CreatedInFolder": {
+			reason:  "A job should be created in its parent folder.",
+			folders: []string{"folder"},
+			mg:      job(withParent("folder")),
+			want:    want{externalName: "folder/job", config: jobConfig},
+		},
+		"CreatedFromPipeline": {
+			reason: "A job should be created from the config.xml generated for its Pipeline.",
+			mg:     job(withPipeline(&v1alpha1.Pipeline{Script: "echo 'hi'"})),
+			want:   want{externalName: "job", config: mustPipelineConfig(t, v1alpha1.Pipeline{Script: "echo 'hi'"})},
+		},
+		"CreatedFromTemplate": {
+			reason: "A job should be created from its template rendered with its variables.",
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+				obj.(*corev1.ConfigMap).Data = map[string]string{"config.xml": template}
+				return nil
+			})},
+			mg: job(withTemplate(
+				&v1alpha1.JobTemplate{ConfigMapKeyRef: &v1alpha1.ConfigMapKeySelector{Name: "templates", Namespace: "default", Key: "config.xml"}},
+				map[string]string{"description": "a & b"})),
+			want: want{externalName: "job", config: `<project><description>a &amp; b</description></project>`},
+		},
+		"AlreadyExists": {
+			reason: "We should return an error if a job with the same name exists.",
+			jobs:   map[string]string{"job": changedJobConfig},
+			mg:     job(),
+			want: want{
+				config: changedJobConfig,
+				err:    errors.Wrap(&clients.APIError{Method: http.MethodPost, Endpoint: "/createItem", Reason: clients.ReasonConflict, Message: "A job already exists with the name ‘job’"}, errCreateJob),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e, s := newExternal(t, tc.kube)
+			for _, n := range tc.folders {
+				s.AddFolder(n)
+			}
+			for n, c := range tc.jobs {
+				s.AddJob(n, c)
+			}
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			cr, ok := tc.mg.(*v1alpha1.Job)
+			if !ok {
+				return
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(cr)); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want external name, +got external name:\n%s", tc.reason, diff)
+			}
+			item, _ := s.GetItem(fullName(cr.Spec.ForProvider))
+			if diff := cmp.Diff(tc.want.config, item.Config); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want config.xml, +got config.xml:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type want struct {
+		externalName string
+		items        []string
+		err          error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		folders []string
+		jobs    map[string]string
+		mg      resource.Managed
+		want    want
+	}{
+		"NotJob": {
+			reason: "We should return an error if the managed resource is not a Job.",
+			want:   want{err: errors.New(errNotJob)},
+		},
+		"ConfigUpdated": {
+			reason: "The config.xml of the job should be replaced.",
+			jobs:   map[string]string{"job": changedJobConfig},
+			mg:     job(withExternalName("job")),
+			want:   want{externalName: "job", items: []string{"job"}},
+		},
+		"Moved": {
+			reason:  "The job should be moved to its new parent and the external name updated.",
+			folders: []string{"folder"},
+			jobs:    map[string]string{"job": changedJobConfig},
+			mg:      job(withExternalName("job"), withParent("folder")),
+			want:    want{externalName: "folder/job", items: []string{"folder", "folder/job"}},
+		},
+		"Renamed": {
+			reason: "The job should be renamed and the external name updated.",
+			jobs:   map[string]string{"old": changedJobConfig},
+			mg:     job(withExternalName("old")),
+			want:   want{externalName: "job", items: []string{"job"}},
+		},
+		"MissingParent": {
+			reason: "We should return an error if the new parent does not exist.",
+			jobs:   map[string]string{"job": changedJobConfig},
+			mg:     job(withExternalName("job"), withParent("folder")),
+			want: want{
+				externalName: "job",
+				items:        []string{"job"},
+				err:          errors.Wrap(&clients.APIError{Method: http.MethodPost, Endpoint: "/job/job/move/move", StatusCode: http.StatusBadRequest, Reason: clients.ReasonUnknown}, "cannot move Jenkins item"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kube := &test.MockClient{MockGet: test.NewMockGetFn(nil), MockUpdate: test.NewMockUpdateFn(nil)}
+			e, s := newExternal(t, kube)
+			for _, n := range tc.folders {
+				s.AddFolder(n)
+			}
+			for n, c := range tc.jobs {
+				s.AddJob(n, c)
+			}
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			cr, ok := tc.mg.(*v1alpha1.Job)
+			if !ok {
+				return
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(cr)); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want external name, +got external name:\n%s", tc.reason, diff)
+			}
+			for _, n := range tc.want.items {
+				if _, ok := s.GetItem(n); !ok {
+					t.Errorf("\n%s\ne.Update(...): item %s does not exist", tc.reason, n)
+				}
+			}
+			if tc.want.err != nil {
+				return
+			}
+			item, _ := s.GetItem(tc.want.externalName)
+			if diff := cmp.Diff(jobConfig, item.Config); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want config.xml, +got config.xml:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		jobs   map[string]string
+		mg     resource.Managed
+		want   error
+	}{
+		"NotJob": {
+			reason: "We should return an error if the managed resource is not a Job.",
+			want:   errors.New(errNotJob),
+		},
+		"NoExternalName": {
+			reason: "A Job without an external name should be deleted without calling Jenkins.",
+			mg:     job(),
+		},
+		"Deleted": {
+			reason: "The job identified by the external name should be deleted.",
+			jobs:   map[string]string{"job": jobConfig},
+			mg:     job(withExternalName("job")),
+		},
+		"NotFound": {
+			reason: "A job that does not exist should be considered deleted.",
+			mg:     job(withExternalName("job")),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e, s := newExternal(t, &test.MockClient{})
+			for n, c := range tc.jobs {
+				s.AddJob(n, c)
+			}
+			err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if _, ok := s.GetItem("job"); ok {
+				t.Errorf("\n%s\ne.Delete(...): job was not deleted", tc.reason)
+			}
+		})
+	}
+}
+
+func mustPipelineConfig(t *testing.T, p v1alpha1.Pipeline) string {
+	t.Helper()
+	c, err := generatePipelineConfig(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
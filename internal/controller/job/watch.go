@@ -0,0 +1,90 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/provider-jenkins/apis/dashboard/v1alpha1"
+)
+
+// configSourceIndex indexes Jobs by the ConfigMaps and Secrets their
+// config.xml or template is loaded from.
+const configSourceIndex = "spec.forProvider.configFrom"
+
+const (
+	kindConfigMap = "ConfigMap"
+	kindSecret    = "Secret"
+)
+
+// configSourceKey identifies a ConfigMap or Secret in the configSourceIndex.
+func configSourceKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+// indexConfigSource returns the configSourceIndex keys of the supplied Job.
+func indexConfigSource(o client.Object) []string {
+	cr, ok := o.(*v1alpha1.Job)
+	if !ok {
+		return nil
+	}
+	keys := []string{}
+	if src := cr.Spec.ForProvider.ConfigFrom; src != nil {
+		if ref := src.ConfigMapKeyRef; ref != nil {
+			keys = append(keys, configSourceKey(kindConfigMap, ref.Namespace, ref.Name))
+		}
+		if ref := src.SecretKeyRef; ref != nil {
+			keys = append(keys, configSourceKey(kindSecret, ref.Namespace, ref.Name))
+		}
+	}
+	if t := cr.Spec.ForProvider.Template; t != nil && t.ConfigMapKeyRef != nil {
+		keys = append(keys, configSourceKey(kindConfigMap, t.ConfigMapKeyRef.Namespace, t.ConfigMapKeyRef.Name))
+	}
+	return keys
+}
+
+// setupConfigSourceIndex registers the configSourceIndex with the supplied
+// manager.
+func setupConfigSourceIndex(mgr ctrl.Manager) error {
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1alpha1.Job{}, configSourceIndex, indexConfigSource)
+	return errors.Wrap(err, "cannot index Jobs by config source")
+}
+
+// enqueueJobsFor returns a handler that enqueues the Jobs whose config.xml or
+// template is loaded from a changed ConfigMap or Secret of the supplied kind.
+func enqueueJobsFor(kube client.Client, kind string) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(o client.Object) []reconcile.Request {
+		jobs := &v1alpha1.JobList{}
+		// Jobs that cannot be listed are still updated when they are next
+		// polled.
+		if err := kube.List(context.Background(), jobs, client.MatchingFields{configSourceIndex: configSourceKey(kind, o.GetNamespace(), o.GetName())}); err != nil {
+			return nil
+		}
+		reqs := make([]reconcile.Request, 0, len(jobs.Items))
+		for _, j := range jobs.Items {
+			reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: j.GetName()}})
+		}
+		return reqs
+	})
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multibranchpipeline
+
+import (
+	"encoding/xml"
+	"reflect"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/provider-jenkins/apis/dashboard/v1alpha1"
+	"github.com/crossplane/provider-jenkins/internal/clients"
+	"github.com/crossplane/provider-jenkins/internal/controller/computedfolder"
+)
+
+const (
+	projectClass         = "org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject"
+	sourceListClass      = "jenkins.branch.MultiBranchProject$BranchSourceList"
+	factoryClass         = "org.jenkinsci.plugins.workflow.multibranch.WorkflowBranchProjectFactory"
+	gitSourceClass       = "jenkins.plugins.git.GitSCMSource"
+	gitHubSourceClass    = "org.jenkinsci.plugins.github_branch_source.GitHubSCMSource"
+	bitbucketSourceClass = "com.cloudbees.jenkins.plugins.bitbucket.BitbucketSCMSource"
+	giteaSourceClass     = "org.jenkinsci.plugin.gitea.GiteaSCMSource"
+
+	defaultScriptPath         = "Jenkinsfile"
+	defaultBitbucketServerURL = "https://bitbucket.org"
+
+	errSourceKind = "exactly one of git, github, bitbucket and gitea must be specified"
+)
+
+// projectConfig is the part of a multibranch project's config.xml managed by a
+// MultibranchPipeline. Elements that are not managed, e.g. views, are
+// defaulted by Jenkins.
+type projectConfig struct {
+	XMLName              xml.Name                            `xml:"org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject"`
+	DisplayName          string                              `xml:"displayName,omitempty"`
+	Description          string                              `xml:"description"`
+	Properties           computedfolder.Properties           `xml:"properties"`
+	OrphanedItemStrategy computedfolder.OrphanedItemStrategy `xml:"orphanedItemStrategy"`
+	Triggers             computedfolder.Triggers             `xml:"triggers"`
+	Disabled             bool                                `xml:"disabled"`
+	Sources              sourceList                          `xml:"sources"`
+	Factory              factory                             `xml:"factory"`
+}
+
+type sourceList struct {
+	Class string               `xml:"class,attr"`
+	Data  []branchSource       `xml:"data>jenkins.branch.BranchSource"`
+	Owner computedfolder.Owner `xml:"owner"`
+}
+
+type branchSource struct {
+	Source   scmSource                             `xml:"source"`
+	Strategy computedfolder.BranchPropertyStrategy `xml:"strategy"`
+}
+
+// scmSource holds the fields of all supported kinds of SCM sources. Each kind
+// uses a subset of them.
+type scmSource struct {
+	Class         string                `xml:"class,attr"`
+	ID            string                `xml:"id"`
+	Remote        string                `xml:"remote,omitempty"`
+	APIURI        string                `xml:"apiUri,omitempty"`
+	ServerURL     string                `xml:"serverUrl,omitempty"`
+	CredentialsID string                `xml:"credentialsId,omitempty"`
+	RepoOwner     string                `xml:"repoOwner,omitempty"`
+	Repository    string                `xml:"repository,omitempty"`
+	Traits        computedfolder.Traits `xml:"traits"`
+}
+
+type factory struct {
+	Class      string               `xml:"class,attr"`
+	Owner      computedfolder.Owner `xml:"owner"`
+	ScriptPath string               `xml:"scriptPath"`
+}
+
+// generateConfig produces the config.xml of the supplied multibranch pipeline
+// parameters. The properties of the supplied observed config.xml, if any, are
+// preserved.
+func generateConfig(p v1alpha1.MultibranchPipelineParameters, observed string) (string, error) {
+	triggers, err := computedfolder.GenerateTriggers(p.ScanInterval)
+	if err != nil {
+		return "", err
+	}
+	properties := computedfolder.Properties{}
+	if observed != "" {
+		if properties, err = computedfolder.ObservedProperties(observed); err != nil {
+			return "", err
+		}
+	}
+	cfg := projectConfig{
+		DisplayName:          p.DisplayName,
+		Description:          p.Description,
+		Properties:           properties,
+		OrphanedItemStrategy: computedfolder.GenerateOrphanedItemStrategy(p.OrphanedItemStrategy),
+		Triggers:             triggers,
+		Disabled:             p.Disabled,
+		Sources:              sourceList{Class: sourceListClass, Owner: computedfolder.Owner{Class: projectClass}},
+		Factory:              factory{Class: factoryClass, Owner: computedfolder.Owner{Class: projectClass}, ScriptPath: p.ScriptPath},
+	}
+	if cfg.Factory.ScriptPath == "" {
+		cfg.Factory.ScriptPath = defaultScriptPath
+	}
+	for i, s := range p.Sources {
+		id := s.ID
+		if id == "" {
+			id = strconv.Itoa(i)
+		}
+		src, err := generateSource(id, s)
+		if err != nil {
+			return "", errors.Wrapf(err, "invalid source %d", i)
+		}
+		cfg.Sources.Data = append(cfg.Sources.Data, branchSource{Source: src})
+	}
+	b, err := xml.MarshalIndent(cfg, "", "  ")
+	return string(b), errors.Wrap(err, "cannot encode config.xml")
+}
+
+// generateSource produces the SCM source of the supplied branch source.
+func generateSource(id string, s v1alpha1.BranchSource) (scmSource, error) {
+	kinds := 0
+	src := scmSource{ID: id}
+	var scm computedfolder.SCM
+	var traits *v1alpha1.SCMTraits
+	if g := s.Git; g != nil {
+		kinds++
+		src.Class, src.Remote, src.CredentialsID = gitSourceClass, g.Remote, g.CredentialsID
+		scm, traits = computedfolder.Git, g.Traits
+	}
+	if g := s.GitHub; g != nil {
+		kinds++
+		src.Class, src.APIURI, src.CredentialsID, src.RepoOwner, src.Repository = gitHubSourceClass, g.APIURI, g.CredentialsID, g.RepoOwner, g.Repository
+		scm, traits = computedfolder.GitHub, g.Traits
+	}
+	if b := s.Bitbucket; b != nil {
+		kinds++
+		src.Class, src.ServerURL, src.CredentialsID, src.RepoOwner, src.Repository = bitbucketSourceClass, b.ServerURL, b.CredentialsID, b.RepoOwner, b.Repository
+		if src.ServerURL == "" {
+			src.ServerURL = defaultBitbucketServerURL
+		}
+		scm, traits = computedfolder.Bitbucket, b.Traits
+	}
+	if g := s.Gitea; g != nil {
+		kinds++
+		src.Class, src.ServerURL, src.CredentialsID, src.RepoOwner, src.Repository = giteaSourceClass, g.ServerURL, g.CredentialsID, g.RepoOwner, g.Repository
+		scm, traits = computedfolder.Gitea, g.Traits
+	}
+	if kinds != 1 {
+		return scmSource{}, errors.New(errSourceKind)
+	}
+	t, err := scm.GenerateTraits(traits)
+	src.Traits = t
+	return src, err
+}
+
+// isUpToDate reports whether the supplied config.xml of a multibranch project
+// matches the supplied desired config.xml.
+func isUpToDate(desired, observed string) (bool, error) {
+	d, o := projectConfig{}, projectConfig{}
+	if err := clients.DecodeXML(desired, &d); err != nil {
+		return false, err
+	}
+	if err := clients.DecodeXML(observed, &o); err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(d, o), nil
+}
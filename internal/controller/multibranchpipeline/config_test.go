@@ -0,0 +1,261 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multibranchpipeline
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-jenkins/apis/dashboard/v1alpha1"
+	"github.com/crossplane/provider-jenkins/internal/controller/computedfolder"
+)
+
+// branchTraits returns the traits discovering branches that are not pull
+// requests with the supplied branch discovery trait.
+func branchTraits(class string, strategyID int) computedfolder.Traits {
+	return computedfolder.Traits{Traits: []computedfolder.Trait{{XMLName: xml.Name{Local: class}, StrategyID: strategyID}}}
+}
+
+func TestGenerateSource(t *testing.T) {
+	type want struct {
+		src scmSource
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		s      v1alpha1.BranchSource
+		want   want
+	}{
+		"Git": {
+			reason: "A Git source should discover branches by default.",
+			s:      v1alpha1.BranchSource{Git: &v1alpha1.GitBranchSource{Remote: "https://example.org/repo.git", CredentialsID: "git"}},
+			want: want{src: scmSource{
+				Class:         gitSourceClass,
+				ID:            "id",
+				Remote:        "https://example.org/repo.git",
+				CredentialsID: "git",
+				Traits:        branchTraits("jenkins.plugins.git.traits.BranchDiscoveryTrait", 0),
+			}},
+		},
+		"GitHub": {
+			reason: "A GitHub source should discover branches that are not pull requests by default.",
+			s:      v1alpha1.BranchSource{GitHub: &v1alpha1.GitHubBranchSource{RepoOwner: "owner", Repository: "repo"}},
+			want: want{src: scmSource{
+				Class:      gitHubSourceClass,
+				ID:         "id",
+				RepoOwner:  "owner",
+				Repository: "repo",
+				Traits:     branchTraits("org.jenkinsci.plugins.github__branch__source.BranchDiscoveryTrait", 1),
+			}},
+		},
+		"BitbucketDefaultServerURL": {
+			reason: "A Bitbucket source should default to Bitbucket Cloud.",
+			s:      v1alpha1.BranchSource{Bitbucket: &v1alpha1.BitbucketBranchSource{RepoOwner: "owner", Repository: "repo"}},
+			want: want{src: scmSource{
+				Class:      bitbucketSourceClass,
+				ID:         "id",
+				ServerURL:  "https://bitbucket.org",
+				RepoOwner:  "owner",
+				Repository: "repo",
+				Traits:     branchTraits("com.cloudbees.jenkins.plugins.bitbucket.BranchDiscoveryTrait", 1),
+			}},
+		},
+		"BitbucketServer": {
+			reason: "A Bitbucket source should use the supplied server URL.",
+			s:      v1alpha1.BranchSource{Bitbucket: &v1alpha1.BitbucketBranchSource{ServerURL: "https://bitbucket.example.org", RepoOwner: "owner", Repository: "repo"}},
+			want: want{src: scmSource{
+				Class:      bitbucketSourceClass,
+				ID:         "id",
+				ServerURL:  "https://bitbucket.example.org",
+				RepoOwner:  "owner",
+				Repository: "repo",
+				Traits:     branchTraits("com.cloudbees.jenkins.plugins.bitbucket.BranchDiscoveryTrait", 1),
+			}},
+		},
+		"Gitea": {
+			reason: "A Gitea source should use the supplied server URL.",
+			s:      v1alpha1.BranchSource{Gitea: &v1alpha1.GiteaBranchSource{ServerURL: "https://gitea.example.org", RepoOwner: "owner", Repository: "repo"}},
+			want: want{src: scmSource{
+				Class:      giteaSourceClass,
+				ID:         "id",
+				ServerURL:  "https://gitea.example.org",
+				RepoOwner:  "owner",
+				Repository: "repo",
+				Traits:     branchTraits("org.jenkinsci.plugin.gitea.BranchDiscoveryTrait", 1),
+			}},
+		},
+		"NoSource": {
+			reason: "We should return an error if no kind of source is specified.",
+			s:      v1alpha1.BranchSource{},
+			want:   want{err: errors.New(errSourceKind)},
+		},
+		"TwoSources": {
+			reason: "We should return an error if more than one kind of source is specified.",
+			s: v1alpha1.BranchSource{
+				Git:    &v1alpha1.GitBranchSource{Remote: "https://example.org/repo.git"},
+				GitHub: &v1alpha1.GitHubBranchSource{RepoOwner: "owner", Repository: "repo"},
+			},
+			want: want{err: errors.New(errSourceKind)},
+		},
+		"GitPullRequests": {
+			reason: "We should return an error if pull requests are discovered from a Git source.",
+			s: v1alpha1.BranchSource{Git: &v1alpha1.GitBranchSource{
+				Remote: "https://example.org/repo.git",
+				Traits: &v1alpha1.SCMTraits{OriginPullRequests: &v1alpha1.PullRequestDiscovery{}},
+			}},
+			want: want{
+				src: scmSource{Class: gitSourceClass, ID: "id", Remote: "https://example.org/repo.git"},
+				err: errors.New("Git does not support pull request discovery"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := generateSource("id", tc.s)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ngenerateSource(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.src, got); diff != "" {
+				t.Errorf("\n%s\ngenerateSource(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateConfig(t *testing.T) {
+	type args struct {
+		p        v1alpha1.MultibranchPipelineParameters
+		observed string
+	}
+	type want struct {
+		contains []string
+		err      error
+	}
+
+	git := v1alpha1.BranchSource{Git: &v1alpha1.GitBranchSource{Remote: "https://example.org/repo.git"}}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"DefaultScriptPath": {
+			reason: "The Jenkinsfile at the root of the repository should be used by default.",
+			args:   args{p: v1alpha1.MultibranchPipelineParameters{Sources: []v1alpha1.BranchSource{git}}},
+			want:   want{contains: []string{"<scriptPath>Jenkinsfile</scriptPath>"}},
+		},
+		"ScriptPath": {
+			reason: "The supplied script path should be used.",
+			args:   args{p: v1alpha1.MultibranchPipelineParameters{ScriptPath: "ci/Jenkinsfile", Sources: []v1alpha1.BranchSource{git}}},
+			want:   want{contains: []string{"<scriptPath>ci/Jenkinsfile</scriptPath>"}},
+		},
+		"SourceIDs": {
+			reason: "Sources without an ID should be identified by their index.",
+			args: args{p: v1alpha1.MultibranchPipelineParameters{Sources: []v1alpha1.BranchSource{
+				{ID: "main", Git: git.Git},
+				git,
+			}}},
+			want: want{contains: []string{"<id>main</id>", "<id>1</id>"}},
+		},
+		"PropertiesPreserved": {
+			reason: "The observed properties, e.g. folder scoped credentials, should be preserved.",
+			args: args{
+				p:        v1alpha1.MultibranchPipelineParameters{Sources: []v1alpha1.BranchSource{git}},
+				observed: `<?xml version='1.1' encoding='UTF-8'?><` + projectClass + `><properties><com.example.Property>kept</com.example.Property></properties></` + projectClass + `>`,
+			},
+			want: want{contains: []string{"<properties><com.example.Property>kept</com.example.Property></properties>"}},
+		},
+		"InvalidSource": {
+			reason: "We should return an error identifying an invalid source.",
+			args:   args{p: v1alpha1.MultibranchPipelineParameters{Sources: []v1alpha1.BranchSource{git, {}}}},
+			want:   want{err: errors.Wrap(errors.New(errSourceKind), "invalid source 1")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := generateConfig(tc.args.p, tc.args.observed)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ngenerateConfig(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			for _, c := range tc.want.contains {
+				if !strings.Contains(got, c) {
+					t.Errorf("\n%s\ngenerateConfig(...): config.xml does not contain %s:\n%s", tc.reason, c, got)
+				}
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	p := v1alpha1.MultibranchPipelineParameters{
+		Description: "project",
+		Sources:     []v1alpha1.BranchSource{{Git: &v1alpha1.GitBranchSource{Remote: "https://example.org/repo.git"}}},
+	}
+	desired, err := generateConfig(p, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		reason   string
+		observed string
+		want     bool
+	}{
+		"UpToDate": {
+			reason:   "The generated config.xml should be up to date.",
+			observed: desired,
+			want:     true,
+		},
+		"UnmanagedElements": {
+			reason: "Elements that are not managed, such as views and properties, should be ignored.",
+			observed: strings.Replace(desired, "<description>project</description>",
+				"<description>project</description><properties><com.example.Property/></properties><views><hudson.model.AllView/></views>", 1),
+			want: true,
+		},
+		"ScriptPathChanged": {
+			reason:   "A config.xml whose script path differs should not be up to date.",
+			observed: strings.Replace(desired, "<scriptPath>Jenkinsfile</scriptPath>", "<scriptPath>ci/Jenkinsfile</scriptPath>", 1),
+			want:     false,
+		},
+		"SourceChanged": {
+			reason:   "A config.xml whose sources differ should not be up to date.",
+			observed: strings.Replace(desired, "https://example.org/repo.git", "https://example.org/other.git", 1),
+			want:     false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := isUpToDate(desired, tc.observed)
+			if err != nil {
+				t.Fatalf("\n%s\nisUpToDate(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nisUpToDate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
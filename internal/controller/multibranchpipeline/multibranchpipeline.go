@@ -0,0 +1,277 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multibranchpipeline
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-jenkins/apis/dashboard/v1alpha1"
+	apisv1alpha1 "github.com/crossplane/provider-jenkins/apis/v1alpha1"
+	"github.com/crossplane/provider-jenkins/internal/clients"
+	"github.com/crossplane/provider-jenkins/internal/controller/computedfolder"
+	"github.com/crossplane/provider-jenkins/internal/controller/features"
+	"github.com/crossplane/provider-jenkins/internal/controller/item"
+)
+
+const (
+	errNotMultibranchPipeline = "managed resource is not a MultibranchPipeline custom resource"
+	errNewClient              = "cannot create Jenkins client"
+	errTrackPCUsage           = "cannot track ProviderConfig usage"
+	errGenerateConfig         = "cannot generate Jenkins multibranch pipeline config"
+	errGetProject             = "cannot get Jenkins multibranch pipeline"
+	errGetProjectConfig       = "cannot get Jenkins multibranch pipeline config"
+	errCompareConfig          = "cannot compare Jenkins multibranch pipeline config"
+	errCreateProject          = "cannot create Jenkins multibranch pipeline"
+	errUpdateProject          = "cannot update Jenkins multibranch pipeline config"
+	errDeleteProject          = "cannot delete Jenkins multibranch pipeline"
+)
+
+// computation is the name of the scan of a multibranch project.
+const computation = "indexing"
+
+// Setup adds a controller that reconciles MultibranchPipeline managed
+// resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.MultibranchPipelineGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	cc := clients.NewClientCache(clients.NewClient)
+	if err := cc.EvictOnDelete(context.Background(), mgr.GetCache()); err != nil {
+		return err
+	}
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.MultibranchPipelineGroupVersionKind),
+		managed.WithExternalConnecter(&connector{
+			kube:    mgr.GetClient(),
+			usage:   resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			clients: cc}),
+		// The external name is the full name of the multibranch pipeline,
+		// which is set by Create rather than defaulted to the name of the
+		// managed resource.
+		managed.WithInitializers(),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.MultibranchPipeline{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube    client.Client
+	usage   resource.Tracker
+	clients *clients.ClientCache
+}
+
+// Connect produces an ExternalClient by tracking that the managed resource is
+// using a ProviderConfig and getting a client for that ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.MultibranchPipeline)
+	if !ok {
+		return nil, errors.New(errNotMultibranchPipeline)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	svc, err := c.clients.Get(ctx, c.kube, cr)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &external{kube: c.kube, service: svc}, nil
+}
+
+// An external observes, then either creates, updates, or deletes a Jenkins
+// multibranch pipeline to ensure it reflects the managed resource's desired
+// state.
+type external struct {
+	kube    client.Client
+	service clients.Client
+}
+
+// fullName returns the full name of the multibranch pipeline, e.g.
+// "team/sub/project".
+func fullName(p v1alpha1.MultibranchPipelineParameters) string {
+	return item.FullName(p.Parent, p.Name)
+}
+
+// generateObservation produces a MultibranchPipelineObservation from the state
+// of a multibranch project and its last scan as reported by Jenkins.
+func generateObservation(f *clients.Folder, scan *v1alpha1.ScanObservation) v1alpha1.MultibranchPipelineObservation {
+	o := v1alpha1.MultibranchPipelineObservation{FullName: f.FullName, URL: f.URL, LastScan: scan}
+	for _, j := range f.Jobs {
+		o.Branches = append(o.Branches, j.Name)
+	}
+	return o
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.MultibranchPipeline)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotMultibranchPipeline)
+	}
+
+	// The external name is the full name of the multibranch pipeline in
+	// Jenkins. It is set by Create, or by the user to import an existing
+	// multibranch pipeline.
+	externalName := meta.GetExternalName(cr)
+	if externalName == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	f, err := c.service.GetFolder(ctx, externalName)
+	if err != nil {
+		if clients.IsNotFound(err) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetProject)
+	}
+	scan, err := computedfolder.ObserveScan(ctx, c.service, externalName, computation)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	cr.Status.AtProvider = generateObservation(f, scan)
+	cr.SetConditions(xpv1.Available())
+
+	upToDate, err := c.upToDate(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: upToDate && !computedfolder.RescanRequested(cr)}, nil
+}
+
+// upToDate reports whether the multibranch pipeline identified by the external
+// name of the supplied MultibranchPipeline matches its desired state. Requested
+// rescans are not part of the desired state.
+func (c *external) upToDate(ctx context.Context, cr *v1alpha1.MultibranchPipeline) (bool, error) {
+	externalName := meta.GetExternalName(cr)
+	if externalName != fullName(cr.Spec.ForProvider) {
+		return false, nil
+	}
+
+	desired, err := generateConfig(cr.Spec.ForProvider, "")
+	if err != nil {
+		return false, errors.Wrap(err, errGenerateConfig)
+	}
+	observed, err := c.service.GetFolderConfig(ctx, externalName)
+	if err != nil {
+		return false, errors.Wrap(err, errGetProjectConfig)
+	}
+	upToDate, err := isUpToDate(desired, observed)
+	return upToDate, errors.Wrap(err, errCompareConfig)
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.MultibranchPipeline)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotMultibranchPipeline)
+	}
+
+	config, err := generateConfig(cr.Spec.ForProvider, "")
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errGenerateConfig)
+	}
+	if err := c.service.CreateFolder(ctx, fullName(cr.Spec.ForProvider), config); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateProject)
+	}
+	meta.SetExternalName(cr, fullName(cr.Spec.ForProvider))
+	// Jenkins scans a multibranch project when it is created.
+	computedfolder.SetRescanned(cr)
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.MultibranchPipeline)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotMultibranchPipeline)
+	}
+
+	// Only a rescan was requested if the multibranch pipeline is up to
+	// date, so its config.xml is left alone.
+	upToDate, err := c.upToDate(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if !upToDate {
+		if err := c.configure(ctx, cr); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+	return managed.ExternalUpdate{}, computedfolder.Rescan(ctx, c.kube, c.service, cr, fullName(cr.Spec.ForProvider))
+}
+
+// configure moves the multibranch pipeline to the parent and name of the
+// supplied MultibranchPipeline and updates its config.xml, preserving its
+// properties.
+func (c *external) configure(ctx context.Context, cr *v1alpha1.MultibranchPipeline) error {
+	if err := item.Move(ctx, c.kube, c.service, cr, cr.Spec.ForProvider.Parent, cr.Spec.ForProvider.Name); err != nil {
+		return err
+	}
+	observed, err := c.service.GetFolderConfig(ctx, fullName(cr.Spec.ForProvider))
+	if err != nil {
+		return errors.Wrap(err, errGetProjectConfig)
+	}
+	config, err := generateConfig(cr.Spec.ForProvider, observed)
+	if err != nil {
+		return errors.Wrap(err, errGenerateConfig)
+	}
+	return errors.Wrap(c.service.UpdateFolderConfig(ctx, fullName(cr.Spec.ForProvider), config), errUpdateProject)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.MultibranchPipeline)
+	if !ok {
+		return errors.New(errNotMultibranchPipeline)
+	}
+
+	// A MultibranchPipeline without an external name was never created in
+	// Jenkins.
+	externalName := meta.GetExternalName(cr)
+	if externalName == "" {
+		return nil
+	}
+
+	// Deleting a multibranch project deletes the jobs of all its branches.
+	err := c.service.DeleteFolder(ctx, externalName)
+	return errors.Wrap(resource.Ignore(clients.IsNotFound, err), errDeleteProject)
+}
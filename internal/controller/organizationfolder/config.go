@@ -0,0 +1,164 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package organizationfolder
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/provider-jenkins/apis/dashboard/v1alpha1"
+	"github.com/crossplane/provider-jenkins/internal/clients"
+	"github.com/crossplane/provider-jenkins/internal/controller/computedfolder"
+)
+
+const (
+	gitHubNavigatorClass    = "org.jenkinsci.plugins.github_branch_source.GitHubSCMNavigator"
+	bitbucketNavigatorClass = "com.cloudbees.jenkins.plugins.bitbucket.BitbucketSCMNavigator"
+	giteaNavigatorClass     = "org.jenkinsci.plugin.gitea.GiteaSCMNavigator"
+
+	defaultScriptPath         = "Jenkinsfile"
+	defaultBitbucketServerURL = "https://bitbucket.org"
+
+	errNavigatorKind = "exactly one of github, bitbucket and gitea must be specified"
+)
+
+// folderConfig is the part of an organization folder's config.xml managed by
+// an OrganizationFolder. Elements that are not managed, e.g. views, are
+// defaulted by Jenkins.
+type folderConfig struct {
+	XMLName              xml.Name                              `xml:"jenkins.branch.OrganizationFolder"`
+	DisplayName          string                                `xml:"displayName,omitempty"`
+	Description          string                                `xml:"description"`
+	Properties           computedfolder.Properties             `xml:"properties"`
+	OrphanedItemStrategy computedfolder.OrphanedItemStrategy   `xml:"orphanedItemStrategy"`
+	Triggers             computedfolder.Triggers               `xml:"triggers"`
+	Navigators           navigators                            `xml:"navigators"`
+	ProjectFactories     projectFactories                      `xml:"projectFactories"`
+	Strategy             computedfolder.BranchPropertyStrategy `xml:"strategy"`
+}
+
+// navigators holds the navigators of an organization folder. The name of each
+// navigator element is its class.
+type navigators struct {
+	Navigators []navigator `xml:",any"`
+}
+
+// navigator holds the fields of all supported kinds of SCM navigators. Each
+// kind uses a subset of them.
+type navigator struct {
+	XMLName       xml.Name
+	APIURI        string                `xml:"apiUri,omitempty"`
+	ServerURL     string                `xml:"serverUrl,omitempty"`
+	RepoOwner     string                `xml:"repoOwner"`
+	CredentialsID string                `xml:"credentialsId,omitempty"`
+	Traits        computedfolder.Traits `xml:"traits"`
+}
+
+type projectFactories struct {
+	Pipeline pipelineFactory `xml:"org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProjectFactory"`
+}
+
+type pipelineFactory struct {
+	ScriptPath string `xml:"scriptPath"`
+}
+
+// generateConfig produces the config.xml of the supplied organization folder
+// parameters. The properties of the supplied observed config.xml, if any, are
+// preserved.
+func generateConfig(p v1alpha1.OrganizationFolderParameters, observed string) (string, error) {
+	triggers, err := computedfolder.GenerateTriggers(p.ScanInterval)
+	if err != nil {
+		return "", err
+	}
+	properties := computedfolder.Properties{}
+	if observed != "" {
+		if properties, err = computedfolder.ObservedProperties(observed); err != nil {
+			return "", err
+		}
+	}
+	cfg := folderConfig{
+		DisplayName:          p.DisplayName,
+		Description:          p.Description,
+		Properties:           properties,
+		OrphanedItemStrategy: computedfolder.GenerateOrphanedItemStrategy(p.OrphanedItemStrategy),
+		Triggers:             triggers,
+		ProjectFactories:     projectFactories{Pipeline: pipelineFactory{ScriptPath: p.ScriptPath}},
+	}
+	if cfg.ProjectFactories.Pipeline.ScriptPath == "" {
+		cfg.ProjectFactories.Pipeline.ScriptPath = defaultScriptPath
+	}
+	for i, n := range p.Navigators {
+		nav, err := generateNavigator(n)
+		if err != nil {
+			return "", errors.Wrapf(err, "invalid navigator %d", i)
+		}
+		cfg.Navigators.Navigators = append(cfg.Navigators.Navigators, nav)
+	}
+	b, err := xml.MarshalIndent(cfg, "", "  ")
+	return string(b), errors.Wrap(err, "cannot encode config.xml")
+}
+
+// generateNavigator produces the navigator element of the supplied SCM
+// navigator.
+func generateNavigator(n v1alpha1.SCMNavigator) (navigator, error) {
+	kinds := 0
+	nav := navigator{}
+	var class string
+	var scm computedfolder.SCM
+	var traits *v1alpha1.SCMTraits
+	var repositories *v1alpha1.WildcardFilter
+	if g := n.GitHub; g != nil {
+		kinds++
+		class, nav.APIURI, nav.RepoOwner, nav.CredentialsID = gitHubNavigatorClass, g.APIURI, g.RepoOwner, g.CredentialsID
+		scm, traits, repositories = computedfolder.GitHub, g.Traits, g.RepositoryFilter
+	}
+	if b := n.Bitbucket; b != nil {
+		kinds++
+		class, nav.ServerURL, nav.RepoOwner, nav.CredentialsID = bitbucketNavigatorClass, b.ServerURL, b.RepoOwner, b.CredentialsID
+		if nav.ServerURL == "" {
+			nav.ServerURL = defaultBitbucketServerURL
+		}
+		scm, traits, repositories = computedfolder.Bitbucket, b.Traits, b.RepositoryFilter
+	}
+	if g := n.Gitea; g != nil {
+		kinds++
+		class, nav.ServerURL, nav.RepoOwner, nav.CredentialsID = giteaNavigatorClass, g.ServerURL, g.RepoOwner, g.CredentialsID
+		scm, traits, repositories = computedfolder.Gitea, g.Traits, g.RepositoryFilter
+	}
+	if kinds != 1 {
+		return navigator{}, errors.New(errNavigatorKind)
+	}
+	nav.XMLName = xml.Name{Local: computedfolder.ElementName(class)}
+	t, err := scm.GenerateNavigatorTraits(traits, repositories)
+	nav.Traits = t
+	return nav, err
+}
+
+// isUpToDate reports whether the supplied config.xml of an organization folder
+// matches the supplied desired config.xml.
+func isUpToDate(desired, observed string) (bool, error) {
+	d, o := folderConfig{}, folderConfig{}
+	if err := clients.DecodeXML(desired, &d); err != nil {
+		return false, err
+	}
+	if err := clients.DecodeXML(observed, &o); err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(d, o), nil
+}
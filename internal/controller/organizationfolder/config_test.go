@@ -0,0 +1,243 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package organizationfolder
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-jenkins/apis/dashboard/v1alpha1"
+	"github.com/crossplane/provider-jenkins/internal/controller/computedfolder"
+)
+
+// branchTrait returns the trait discovering branches that are not pull
+// requests with the supplied branch discovery trait.
+func branchTrait(class string) computedfolder.Trait {
+	return computedfolder.Trait{XMLName: xml.Name{Local: class}, StrategyID: 1}
+}
+
+func TestGenerateNavigator(t *testing.T) {
+	type want struct {
+		nav navigator
+		err error
+	}
+
+	gitHubBranches := branchTrait("org.jenkinsci.plugins.github__branch__source.BranchDiscoveryTrait")
+
+	cases := map[string]struct {
+		reason string
+		n      v1alpha1.SCMNavigator
+		want   want
+	}{
+		"GitHub": {
+			reason: "A GitHub navigator should discover branches that are not pull requests by default.",
+			n:      v1alpha1.SCMNavigator{GitHub: &v1alpha1.GitHubNavigator{RepoOwner: "owner", CredentialsID: "github"}},
+			want: want{nav: navigator{
+				XMLName:       xml.Name{Local: computedfolder.ElementName(gitHubNavigatorClass)},
+				RepoOwner:     "owner",
+				CredentialsID: "github",
+				Traits:        computedfolder.Traits{Traits: []computedfolder.Trait{gitHubBranches}},
+			}},
+		},
+		"RepositoryFilter": {
+			reason: "A repository filter should precede the other traits and include all repositories by default.",
+			n: v1alpha1.SCMNavigator{GitHub: &v1alpha1.GitHubNavigator{
+				APIURI:           "https://github.example.org/api/v3",
+				RepoOwner:        "owner",
+				RepositoryFilter: &v1alpha1.WildcardFilter{Excludes: "archived-*"},
+			}},
+			want: want{nav: navigator{
+				XMLName:   xml.Name{Local: computedfolder.ElementName(gitHubNavigatorClass)},
+				APIURI:    "https://github.example.org/api/v3",
+				RepoOwner: "owner",
+				Traits: computedfolder.Traits{Traits: []computedfolder.Trait{
+					{XMLName: xml.Name{Local: "jenkins.scm.impl.trait.WildcardSCMSourceFilterTrait"}, Includes: "*", Excludes: "archived-*"},
+					gitHubBranches,
+				}},
+			}},
+		},
+		"BitbucketDefaultServerURL": {
+			reason: "A Bitbucket navigator should default to Bitbucket Cloud.",
+			n:      v1alpha1.SCMNavigator{Bitbucket: &v1alpha1.BitbucketNavigator{RepoOwner: "owner"}},
+			want: want{nav: navigator{
+				XMLName:   xml.Name{Local: bitbucketNavigatorClass},
+				ServerURL: "https://bitbucket.org",
+				RepoOwner: "owner",
+				Traits:    computedfolder.Traits{Traits: []computedfolder.Trait{branchTrait("com.cloudbees.jenkins.plugins.bitbucket.BranchDiscoveryTrait")}},
+			}},
+		},
+		"BitbucketServer": {
+			reason: "A Bitbucket navigator should use the supplied server URL.",
+			n:      v1alpha1.SCMNavigator{Bitbucket: &v1alpha1.BitbucketNavigator{ServerURL: "https://bitbucket.example.org", RepoOwner: "owner"}},
+			want: want{nav: navigator{
+				XMLName:   xml.Name{Local: bitbucketNavigatorClass},
+				ServerURL: "https://bitbucket.example.org",
+				RepoOwner: "owner",
+				Traits:    computedfolder.Traits{Traits: []computedfolder.Trait{branchTrait("com.cloudbees.jenkins.plugins.bitbucket.BranchDiscoveryTrait")}},
+			}},
+		},
+		"Gitea": {
+			reason: "A Gitea navigator should use the supplied server URL.",
+			n:      v1alpha1.SCMNavigator{Gitea: &v1alpha1.GiteaNavigator{ServerURL: "https://gitea.example.org", RepoOwner: "owner"}},
+			want: want{nav: navigator{
+				XMLName:   xml.Name{Local: giteaNavigatorClass},
+				ServerURL: "https://gitea.example.org",
+				RepoOwner: "owner",
+				Traits:    computedfolder.Traits{Traits: []computedfolder.Trait{branchTrait("org.jenkinsci.plugin.gitea.BranchDiscoveryTrait")}},
+			}},
+		},
+		"NoNavigator": {
+			reason: "We should return an error if no kind of navigator is specified.",
+			n:      v1alpha1.SCMNavigator{},
+			want:   want{err: errors.New(errNavigatorKind)},
+		},
+		"TwoNavigators": {
+			reason: "We should return an error if more than one kind of navigator is specified.",
+			n: v1alpha1.SCMNavigator{
+				GitHub: &v1alpha1.GitHubNavigator{RepoOwner: "owner"},
+				Gitea:  &v1alpha1.GiteaNavigator{ServerURL: "https://gitea.example.org", RepoOwner: "owner"},
+			},
+			want: want{err: errors.New(errNavigatorKind)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := generateNavigator(tc.n)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ngenerateNavigator(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.nav, got); diff != "" {
+				t.Errorf("\n%s\ngenerateNavigator(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateConfig(t *testing.T) {
+	type args struct {
+		p        v1alpha1.OrganizationFolderParameters
+		observed string
+	}
+	type want struct {
+		contains []string
+		err      error
+	}
+
+	github := v1alpha1.SCMNavigator{GitHub: &v1alpha1.GitHubNavigator{RepoOwner: "owner"}}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"DefaultScriptPath": {
+			reason: "The Jenkinsfile at the root of each repository should be used by default.",
+			args:   args{p: v1alpha1.OrganizationFolderParameters{Navigators: []v1alpha1.SCMNavigator{github}}},
+			want:   want{contains: []string{"<scriptPath>Jenkinsfile</scriptPath>"}},
+		},
+		"ScriptPath": {
+			reason: "The supplied script path should be used.",
+			args:   args{p: v1alpha1.OrganizationFolderParameters{ScriptPath: "ci/Jenkinsfile", Navigators: []v1alpha1.SCMNavigator{github}}},
+			want:   want{contains: []string{"<scriptPath>ci/Jenkinsfile</scriptPath>"}},
+		},
+		"PropertiesPreserved": {
+			reason: "The observed properties, e.g. folder scoped credentials, should be preserved.",
+			args: args{
+				p:        v1alpha1.OrganizationFolderParameters{Navigators: []v1alpha1.SCMNavigator{github}},
+				observed: `<?xml version='1.1' encoding='UTF-8'?><jenkins.branch.OrganizationFolder><properties><com.example.Property>kept</com.example.Property></properties></jenkins.branch.OrganizationFolder>`,
+			},
+			want: want{contains: []string{"<properties><com.example.Property>kept</com.example.Property></properties>"}},
+		},
+		"InvalidNavigator": {
+			reason: "We should return an error identifying an invalid navigator.",
+			args:   args{p: v1alpha1.OrganizationFolderParameters{Navigators: []v1alpha1.SCMNavigator{github, {}}}},
+			want:   want{err: errors.Wrap(errors.New(errNavigatorKind), "invalid navigator 1")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := generateConfig(tc.args.p, tc.args.observed)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ngenerateConfig(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			for _, c := range tc.want.contains {
+				if !strings.Contains(got, c) {
+					t.Errorf("\n%s\ngenerateConfig(...): config.xml does not contain %s:\n%s", tc.reason, c, got)
+				}
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	p := v1alpha1.OrganizationFolderParameters{
+		Description: "organization",
+		Navigators:  []v1alpha1.SCMNavigator{{GitHub: &v1alpha1.GitHubNavigator{RepoOwner: "owner"}}},
+	}
+	desired, err := generateConfig(p, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		reason   string
+		observed string
+		want     bool
+	}{
+		"UpToDate": {
+			reason:   "The generated config.xml should be up to date.",
+			observed: desired,
+			want:     true,
+		},
+		"UnmanagedElements": {
+			reason: "Elements that are not managed, such as views and properties, should be ignored.",
+			observed: strings.Replace(desired, "<description>organization</description>",
+				"<description>organization</description><properties><com.example.Property/></properties><views><hudson.model.AllView/></views>", 1),
+			want: true,
+		},
+		"ScriptPathChanged": {
+			reason:   "A config.xml whose script path differs should not be up to date.",
+			observed: strings.Replace(desired, "<scriptPath>Jenkinsfile</scriptPath>", "<scriptPath>ci/Jenkinsfile</scriptPath>", 1),
+			want:     false,
+		},
+		"NavigatorChanged": {
+			reason:   "A config.xml whose navigators differ should not be up to date.",
+			observed: strings.Replace(desired, "<repoOwner>owner</repoOwner>", "<repoOwner>other</repoOwner>", 1),
+			want:     false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := isUpToDate(desired, tc.observed)
+			if err != nil {
+				t.Fatalf("\n%s\nisUpToDate(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nisUpToDate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package organizationfolder
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-jenkins/apis/dashboard/v1alpha1"
+	"github.com/crossplane/provider-jenkins/internal/clients"
+	"github.com/crossplane/provider-jenkins/internal/clients/fake"
+)
+
+// views is an element of config.xml that is not managed by an
+// OrganizationFolder, and is dropped when config.xml is generated.
+const views = "<views><hudson.model.AllView/></views>"
+
+func TestUpdate(t *testing.T) {
+	type want struct {
+		scans  int
+		config func(observed string) string
+	}
+
+	unchanged := func(observed string) string { return observed }
+
+	cases := map[string]struct {
+		reason      string
+		description string
+		rescan      bool
+		want        want
+	}{
+		"RescanOnly": {
+			reason: "A rescan of an up to date organization folder should be scheduled without updating its config.xml.",
+			rescan: true,
+			want:   want{scans: 1, config: unchanged},
+		},
+		"ConfigChanged": {
+			reason:      "A organization folder that differs should have its config.xml updated.",
+			description: "changed",
+			want:        want{config: func(string) string { return "<description>changed</description>" }},
+		},
+		"ConfigChangedAndRescan": {
+			reason:      "A organization folder that differs should have its config.xml updated and be rescanned.",
+			description: "changed",
+			rescan:      true,
+			want:        want{scans: 1, config: func(string) string { return "<description>changed</description>" }},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := fake.NewServer()
+			t.Cleanup(s.Close)
+			svc, err := clients.NewClient(s.Config())
+			if err != nil {
+				t.Fatal(err)
+			}
+			e := &external{kube: &test.MockClient{MockGet: test.NewMockGetFn(nil), MockUpdate: test.NewMockUpdateFn(nil)}, service: svc}
+
+			cr := &v1alpha1.OrganizationFolder{Spec: v1alpha1.OrganizationFolderSpec{ForProvider: v1alpha1.OrganizationFolderParameters{
+				Name:        "organization",
+				Description: "organization",
+				Navigators:  []v1alpha1.SCMNavigator{{GitHub: &v1alpha1.GitHubNavigator{RepoOwner: "owner"}}},
+			}}}
+			config, err := generateConfig(cr.Spec.ForProvider, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			observed := strings.Replace(config, "<description>organization</description>", "<description>organization</description>"+views, 1)
+			if err := svc.CreateFolder(context.Background(), "organization", observed); err != nil {
+				t.Fatal(err)
+			}
+			meta.SetExternalName(cr, "organization")
+			if tc.description != "" {
+				cr.Spec.ForProvider.Description = tc.description
+			}
+			if tc.rescan {
+				meta.AddAnnotations(cr, map[string]string{v1alpha1.AnnotationKeyRescan: "1"})
+			}
+
+			if _, err := e.Update(context.Background(), cr); err != nil {
+				t.Fatalf("\n%s\ne.Update(...): unexpected error: %v", tc.reason, err)
+			}
+			item, _ := s.GetItem("organization")
+			if diff := cmp.Diff(tc.want.scans, item.Scans); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want scans, +got scans:\n%s", tc.reason, diff)
+			}
+			if want := tc.want.config(observed); !strings.Contains(item.Config, want) {
+				t.Errorf("\n%s\ne.Update(...): config.xml does not contain %s:\n%s", tc.reason, want, item.Config)
+			}
+		})
+	}
+}